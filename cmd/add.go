@@ -1,69 +1,131 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"news4coder/internal/storage"
+	"news4coder/internal/official"
+	"news4coder/internal/search"
 	"news4coder/internal/subscription"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var (
-	addName  string
-	addAlias string
-	addURL   string
+	addName       string
+	addAlias      string
+	addURL        string
+	addFeedURL    string
+	addNoDiscover bool
+	addBackend    string
+	addCategory   string
+	addTags       []string
 )
 
 var addCmd = &cobra.Command{
 	Use:   "add",
 	Short: "添加新的订阅",
-	Long:  `添加一个新的网站订阅，指定订阅名称、别名和URL。`,
+	Long: `添加一个新的网站订阅，指定订阅名称、别名和URL。
+
+添加时会自动查找网站的 RSS/Atom 订阅源（页面中的 <link rel="alternate"> 声明
+以及 /feed、/rss.xml、/atom.xml 等常见路径）。找到订阅源后，fetch 将直接读取
+订阅源；否则回退到站内搜索。`,
 	Example: `  news4coder add --name "InfoQ中文站" --alias infoq --url "https://www.infoq.cn"
-  news4coder add -n "Hacker News" -a hn -u "https://news.ycombinator.com"`,
+  news4coder add -n "Hacker News" -a hn -u "https://news.ycombinator.com"
+  news4coder add -n "Go Blog" -a goblog -u "https://go.dev/blog" --feed "https://go.dev/blog/feed.atom"
+  news4coder add -n "技术博客" -u "https://example.com" --no-discover --backend bing
+  news4coder add -n "web.dev" -a webdev -u "https://web.dev/blog" --tag frontend --tag perf`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// 创建存储实例
-		store, err := storage.New()
+		store, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("初始化存储失败: %w", err)
 		}
 
-		// 加载现有配置
-		config, err := store.Load()
-		if err != nil {
-			return fmt.Errorf("加载配置失败: %w", err)
+		sub := subscription.Subscription{
+			Name:     addName,
+			Alias:    addAlias,
+			URL:      addURL,
+			FeedURL:  addFeedURL,
+			Backend:  addBackend,
+			Category: addCategory,
+			Tags:     addTags,
 		}
 
-		// 创建订阅管理器
-		manager := subscription.NewManager(config)
+		// 自动发现订阅源（在加锁之前完成，避免网络请求期间阻塞其他命令）；
+		// 先校验订阅，URL 无效或名称重复时不必发起网络请求
+		if sub.FeedURL == "" && !addNoDiscover {
+			config, err := store.Load()
+			if err != nil {
+				return fmt.Errorf("加载配置失败: %w", err)
+			}
+			if err := newManager(config).ValidateNew(sub); err != nil {
+				return err
+			}
+			sub.FeedURL = discoverFeed(cmd.Context(), sub.URL)
+		}
 
 		// 添加订阅
-		if err := manager.Add(addName, addAlias, addURL); err != nil {
+		var added *subscription.Subscription
+		err = store.Update(func(config *subscription.Config) error {
+			added, err = newManager(config).Add(sub)
+			return err
+		})
+		if err != nil {
 			return err
 		}
 
-		// 保存配置
-		if err := store.Save(config); err != nil {
-			return fmt.Errorf("保存配置失败: %w", err)
-		}
-
-		// 输出成功消息
+		// 输出成功消息（显示实际保存的内容）
 		green := color.New(color.FgGreen).SprintFunc()
-		fmt.Printf("%s 成功添加订阅：%s\n", green("✓"), addName)
-		if addAlias != "" {
-			fmt.Printf("  别名: %s\n", addAlias)
+		fmt.Printf("%s 成功添加订阅：%s\n", green("✓"), added.Name)
+		if added.Alias != "" {
+			fmt.Printf("  别名: %s\n", added.Alias)
+		}
+		fmt.Printf("  URL: %s\n", added.URL)
+		if added.FeedURL != "" {
+			fmt.Printf("  订阅源: %s\n", added.FeedURL)
+		}
+		if added.Backend != "" {
+			fmt.Printf("  搜索后端: %s\n", added.Backend)
+		}
+		if added.Category != "" {
+			fmt.Printf("  分类: %s\n", added.Category)
+		}
+		if len(added.Tags) > 0 {
+			fmt.Printf("  标签: %s\n", strings.Join(added.Tags, ", "))
 		}
-		fmt.Printf("  URL: %s\n", addURL)
 
 		return nil
 	},
 }
 
+// discoverFeed 查找网站的订阅源，未找到时返回空字符串
+func discoverFeed(ctx context.Context, siteURL string) string {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("%s 正在查找 %s 的订阅源...\n", cyan("⟳"), siteURL)
+
+	feedURL, err := official.NewFeedDiscoverer().Discover(ctx, siteURL)
+	if err != nil {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("%s 未找到订阅源，将使用站内搜索（%v）\n", yellow("!"), err)
+		return ""
+	}
+	return feedURL
+}
+
 func init() {
 	rootCmd.AddCommand(addCmd)
 	addCmd.Flags().StringVarP(&addName, "name", "n", "", "订阅名称（必填）")
 	addCmd.Flags().StringVarP(&addAlias, "alias", "a", "", "订阅别名/代号（用于快捷访问）")
 	addCmd.Flags().StringVarP(&addURL, "url", "u", "", "网站URL（必填）")
+	addCmd.Flags().StringVar(&addFeedURL, "feed", "", "RSS/Atom 订阅源地址（不指定时自动发现）")
+	addCmd.Flags().BoolVar(&addNoDiscover, "no-discover", false, "跳过订阅源自动发现")
+	addCmd.Flags().StringVar(&addBackend, "backend", "", "站内搜索后端: duckduckgo, bing, searxng（默认使用全局设置）")
+	addCmd.Flags().StringVar(&addCategory, "category", "", "订阅分类")
+	addCmd.Flags().StringSliceVar(&addTags, "tag", nil, "订阅标签（可重复指定或用逗号分隔）")
+	registerFlagCompletion(addCmd, "backend", completeValues(search.BackendNames()...))
+	registerFlagCompletion(addCmd, "tag", completeTags)
 	addCmd.MarkFlagRequired("name")
 	addCmd.MarkFlagRequired("url")
 }
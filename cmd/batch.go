@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news4coder/internal/official"
+	"news4coder/internal/search"
+	"news4coder/internal/subscription"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// fetchTarget 批量获取的单个信息源，official 与 sub 二选一
+type fetchTarget struct {
+	name     string
+	official *official.Source
+	sub      *subscription.Subscription
+}
+
+// fetch 获取信息源内容
+func (t fetchTarget) fetch(ctx context.Context, demo bool) (search.ResultSet, error) {
+	if t.official != nil {
+		return fetchOfficialSource(ctx, t.official, demo)
+	}
+	return fetchUserSubscription(ctx, t.sub, demo)
+}
+
+// fetchOutcome 单个信息源的获取结果
+type fetchOutcome struct {
+	target   fetchTarget
+	set      search.ResultSet
+	err      error
+	duration time.Duration
+}
+
+// resolveFetchTargets 将名称或别名解析为信息源列表，并加入带有任一指定标签的订阅；
+// all 为 true 时包含全部信息源
+func resolveFetchTargets(names, tags []string, all bool) ([]fetchTarget, error) {
+	registry := official.GetRegistry()
+
+	// 用户订阅仅在需要时加载
+	var manager *subscription.Manager
+	loadManager := func() (*subscription.Manager, error) {
+		if manager != nil {
+			return manager, nil
+		}
+		store, err := openStorage()
+		if err != nil {
+			return nil, fmt.Errorf("初始化存储失败: %w", err)
+		}
+		config, err := store.Load()
+		if err != nil {
+			return nil, fmt.Errorf("加载配置失败: %w", err)
+		}
+		manager = newManager(config)
+		return manager, nil
+	}
+
+	var targets []fetchTarget
+	seen := make(map[string]bool)
+	addTarget := func(t fetchTarget) {
+		if seen[t.name] {
+			return
+		}
+		seen[t.name] = true
+		targets = append(targets, t)
+	}
+
+	if all {
+		for _, source := range registry.List() {
+			addTarget(fetchTarget{name: source.Name, official: source})
+		}
+		m, err := loadManager()
+		if err != nil {
+			return nil, err
+		}
+		for _, sub := range m.List() {
+			addTarget(fetchTarget{name: sub.Name, sub: &sub})
+		}
+	}
+
+	for _, tag := range tags {
+		m, err := loadManager()
+		if err != nil {
+			return nil, err
+		}
+		subs := m.ListByTag(tag)
+		if len(subs) == 0 {
+			return nil, fmt.Errorf("没有带标签 %s 的订阅", tag)
+		}
+		for _, sub := range subs {
+			addTarget(fetchTarget{name: sub.Name, sub: &sub})
+		}
+	}
+
+	for _, name := range names {
+		if source, exists := registry.Get(name); exists {
+			addTarget(fetchTarget{name: source.Name, official: source})
+			continue
+		}
+		m, err := loadManager()
+		if err != nil {
+			return nil, err
+		}
+		sub, err := m.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		addTarget(fetchTarget{name: sub.Name, sub: sub})
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("没有可获取的信息源")
+	}
+
+	return targets, nil
+}
+
+// runBatchFetch 并发获取多个信息源，按信息源分组显示结果并在最后报告失败项；
+// ctx 被取消（如 Ctrl-C）时停止获取，仍显示已完成的结果。
+// 机器可读输出时，进度与失败报告写入标准错误，标准输出只包含结果数据
+func runBatchFetch(ctx context.Context, targets []fetchTarget, demo bool) error {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	concurrency := fetchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	status := statusOut()
+	fmt.Fprintf(status, "%s 正在获取 %d 个信息源（并发数 %d）...\n\n", cyan("⟳"), len(targets), concurrency)
+
+	outcomes := collectBatch(ctx, targets, demo, concurrency, fetchTimeout, fetchDeadline, func(o fetchOutcome) {
+		if o.err != nil {
+			fmt.Fprintf(status, "  %s %s\n", red("✗"), o.target.name)
+		} else {
+			fmt.Fprintf(status, "  %s %s（%d 条，%.1fs）\n", green("✓"), o.target.name, len(o.set.Results), o.duration.Seconds())
+		}
+	})
+	fmt.Fprintln(status)
+
+	// 记录历史
+	var sets []search.ResultSet
+	var succeeded []int
+	for i, o := range outcomes {
+		if o.err == nil {
+			sets = append(sets, o.set)
+			succeeded = append(succeeded, i)
+		}
+	}
+	sets = recordHistory(fetchOnlyNew, sets...)
+	for j, set := range sets {
+		outcomes[succeeded[j]].set = set
+	}
+
+	// 按信息源分组显示结果
+	var failed []fetchOutcome
+	for _, o := range outcomes {
+		if o.err != nil {
+			failed = append(failed, o)
+		}
+	}
+	if isMachineOutput() {
+		warnDemoData(os.Stderr, sets)
+		if err := writeResultSets(os.Stdout, sets); err != nil {
+			return err
+		}
+	} else {
+		for _, o := range outcomes {
+			if o.err == nil {
+				displayResultSet(o.set)
+				fmt.Println()
+			}
+		}
+	}
+
+	// 汇总
+	fmt.Fprintln(status, bold(fmt.Sprintf("━━━ 成功 %d 个，失败 %d 个 ━━━", len(outcomes)-len(failed), len(failed))))
+	if len(failed) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(status)
+	for _, o := range failed {
+		fmt.Fprintf(status, "%s %s\n", red("✗"), bold(o.target.name))
+		fmt.Fprintf(status, "   %s\n", strings.ReplaceAll(o.err.Error(), "\n", "\n   "))
+	}
+	fmt.Fprintln(status)
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("已中断，%d 个信息源未完成", len(failed))
+	}
+	return fmt.Errorf("%d 个信息源获取失败", len(failed))
+}
+
+// collectBatch 使用有界工作池获取信息源，结果按输入顺序返回；
+// onDone 在每个信息源完成时调用（串行调用）
+func collectBatch(ctx context.Context, targets []fetchTarget, demo bool, concurrency int, timeout, deadline time.Duration, onDone func(fetchOutcome)) []fetchOutcome {
+	outcomes := make([]fetchOutcome, len(targets))
+
+	// 总时限到期后，未完成的信息源立即结束
+	ctx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				o := fetchWithTimeout(ctx, targets[i], demo, timeout)
+				outcomes[i] = o
+
+				mu.Lock()
+				onDone(o)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range targets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return outcomes
+}
+
+// fetchWithTimeout 获取单个信息源，超过单源超时、总时限或被取消时返回错误
+func fetchWithTimeout(ctx context.Context, target fetchTarget, demo bool, timeout time.Duration) fetchOutcome {
+	start := time.Now()
+	outcome := fetchOutcome{target: target}
+
+	if err := ctx.Err(); err != nil {
+		outcome.err = batchContextError(err, "未开始获取")
+		return outcome
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	outcome.set, outcome.err = target.fetch(fetchCtx, demo)
+	outcome.duration = time.Since(start)
+
+	switch {
+	case outcome.err == nil:
+	case ctx.Err() != nil:
+		outcome.err = batchContextError(ctx.Err(), "获取未完成")
+	case errors.Is(fetchCtx.Err(), context.DeadlineExceeded):
+		outcome.err = fmt.Errorf("获取超时（%s）", timeout)
+	}
+
+	return outcome
+}
+
+// batchContextError 将总时限到期或取消转换为可读的错误
+func batchContextError(err error, detail string) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("已超过总时限，%s", detail)
+	}
+	return fmt.Errorf("已取消，%s", detail)
+}
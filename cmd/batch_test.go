@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"news4coder/internal/official"
+	"news4coder/internal/search"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchTestFetcher 模拟抓取器：等待 delay 后返回一条结果或 fail 指定的错误，等待期间响应 ctx 取消
+type batchTestFetcher struct {
+	name  string
+	delay time.Duration
+	fail  string
+}
+
+func (f *batchTestFetcher) Fetch(ctx context.Context) ([]search.SearchResult, error) {
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if f.fail != "" {
+		return nil, errors.New(f.fail)
+	}
+	return []search.SearchResult{{Index: 1, Title: f.name, URL: "https://example.com/" + f.name}}, nil
+}
+
+func init() {
+	official.RegisterFetcher(official.FetcherType{
+		Name:        "batch-test",
+		Description: "批量获取测试用抓取器",
+		Schema: official.ConfigSchema{
+			Options: []official.OptionSpec{{Name: "delay"}, {Name: "fail"}},
+		},
+		New: func(source *official.Source) (official.Fetcher, error) {
+			f := &batchTestFetcher{name: source.Name, fail: source.Options["fail"]}
+			if d := source.Options["delay"]; d != "" {
+				delay, err := time.ParseDuration(d)
+				if err != nil {
+					return nil, err
+				}
+				f.delay = delay
+			}
+			return f, nil
+		},
+	})
+}
+
+// batchTarget 创建使用测试抓取器的信息源
+func batchTarget(name string, delay time.Duration, fail string) fetchTarget {
+	options := map[string]string{"delay": delay.String()}
+	if fail != "" {
+		options["fail"] = fail
+	}
+	return fetchTarget{name: name, official: &official.Source{
+		Alias:       name,
+		Name:        name,
+		URL:         "https://example.com/" + name,
+		FetcherType: "batch-test",
+		Options:     options,
+	}}
+}
+
+// collectNames 运行 collectBatch 并按完成顺序记录信息源名称
+func collectNames(ctx context.Context, targets []fetchTarget, concurrency int, timeout, deadline time.Duration) ([]fetchOutcome, []string) {
+	var mu sync.Mutex
+	var done []string
+	outcomes := collectBatch(ctx, targets, false, concurrency, timeout, deadline, func(o fetchOutcome) {
+		mu.Lock()
+		done = append(done, o.target.name)
+		mu.Unlock()
+	})
+	return outcomes, done
+}
+
+func TestCollectBatchKeepsInputOrder(t *testing.T) {
+	targets := []fetchTarget{
+		batchTarget("slow", 150*time.Millisecond, ""),
+		batchTarget("fast", 0, ""),
+		batchTarget("broken", 50*time.Millisecond, "boom"),
+	}
+	outcomes, done := collectNames(context.Background(), targets, len(targets), 5*time.Second, 10*time.Second)
+
+	if len(outcomes) != len(targets) || len(done) != len(targets) {
+		t.Fatalf("got %d outcomes and %d callbacks, want %d", len(outcomes), len(done), len(targets))
+	}
+	for i, o := range outcomes {
+		if o.target.name != targets[i].name {
+			t.Errorf("outcome %d = %s, want %s", i, o.target.name, targets[i].name)
+		}
+	}
+	if done[0] != "fast" || done[2] != "slow" {
+		t.Errorf("completion order = %v, want fast first and slow last", done)
+	}
+
+	if outcomes[0].err != nil || outcomes[0].set.Results[0].Title != "slow" {
+		t.Errorf("slow: err = %v, set = %+v", outcomes[0].err, outcomes[0].set)
+	}
+	if outcomes[2].err == nil || !strings.Contains(outcomes[2].err.Error(), "boom") {
+		t.Errorf("broken: err = %v, want boom", outcomes[2].err)
+	}
+}
+
+func TestCollectBatchPerSourceTimeout(t *testing.T) {
+	targets := []fetchTarget{
+		batchTarget("hang", 5*time.Second, ""),
+		batchTarget("quick", 0, ""),
+	}
+	outcomes, _ := collectNames(context.Background(), targets, 2, 100*time.Millisecond, 10*time.Second)
+
+	if err := outcomes[0].err; err == nil || err.Error() != "获取超时（100ms）" {
+		t.Errorf("hang: err = %v, want 获取超时（100ms）", err)
+	}
+	if outcomes[1].err != nil {
+		t.Errorf("quick: err = %v", outcomes[1].err)
+	}
+}
+
+func TestCollectBatchDeadline(t *testing.T) {
+	targets := []fetchTarget{
+		batchTarget("hang", 5*time.Second, ""),
+		batchTarget("queued-1", 0, ""),
+		batchTarget("queued-2", 0, ""),
+	}
+	start := time.Now()
+	outcomes, _ := collectNames(context.Background(), targets, 1, 5*time.Second, 100*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("collectBatch took %v after the deadline", elapsed)
+	}
+
+	if err := outcomes[0].err; err == nil || err.Error() != "已超过总时限，获取未完成" {
+		t.Errorf("hang: err = %v", err)
+	}
+	for _, o := range outcomes[1:] {
+		if o.err == nil || o.err.Error() != "已超过总时限，未开始获取" {
+			t.Errorf("%s: err = %v, want 未开始获取", o.target.name, o.err)
+		}
+	}
+}
+
+func TestCollectBatchCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	targets := []fetchTarget{
+		batchTarget("hang", 5*time.Second, ""),
+		batchTarget("queued", 0, ""),
+	}
+	outcomes, _ := collectNames(ctx, targets, 1, 5*time.Second, 10*time.Second)
+
+	if err := outcomes[0].err; err == nil || err.Error() != "已取消，获取未完成" {
+		t.Errorf("hang: err = %v", err)
+	}
+	if err := outcomes[1].err; err == nil || err.Error() != "已取消，未开始获取" {
+		t.Errorf("queued: err = %v", err)
+	}
+}
+
+func TestFetchRejectsInvalidBackendOnce(t *testing.T) {
+	t.Cleanup(func() {
+		fetchBackend = ""
+		configPath = ""
+		rootCmd.SetArgs(nil)
+	})
+
+	// 信息源不存在：若先解析信息源会报告“订阅不存在”，说明后端没有提前校验
+	config := filepath.Join(t.TempDir(), "subscriptions.json")
+	rootCmd.SetArgs([]string{"fetch", "missing-1", "missing-2", "--backend", "nope", "--config", config})
+	err := rootCmd.ExecuteContext(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "不支持的搜索后端: nope") {
+		t.Fatalf("err = %v, want invalid backend", err)
+	}
+	if n := strings.Count(err.Error(), "nope"); n != 1 {
+		t.Errorf("backend error repeated %d times: %v", n, err)
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"news4coder/internal/history"
+	"news4coder/internal/storage"
+	"news4coder/internal/subscription"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useMemoryStorage 让命令使用内存存储和临时目录下的历史记录，并确认没有写入默认位置
+func useMemoryStorage(t *testing.T) (*storage.MemoryStorage, string) {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv(storage.EnvHome, home)
+	t.Cleanup(func() {
+		entries, _ := os.ReadDir(home)
+		if len(entries) != 0 {
+			t.Errorf("commands wrote to the default data directory: %d entries", len(entries))
+		}
+	})
+
+	store := storage.NewMemory(nil)
+	SetStorage(store)
+	historyPath := filepath.Join(t.TempDir(), "history.json")
+	SetHistoryStore(history.NewFile(historyPath))
+	t.Cleanup(func() {
+		SetStorage(nil)
+		SetHistoryStore(nil)
+	})
+	return store, historyPath
+}
+
+func listSubscriptions(t *testing.T, args ...string) []subscription.Subscription {
+	t.Helper()
+	out, err := executeCommand(t, append([]string{"list", "-o", "json"}, args...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var subs []subscription.Subscription
+	if err := json.Unmarshal([]byte(out), &subs); err != nil {
+		t.Fatalf("list output is not JSON: %v\n%s", err, out)
+	}
+	return subs
+}
+
+func TestAddListWithMemoryStorage(t *testing.T) {
+	store, _ := useMemoryStorage(t)
+
+	out, err := executeCommand(t, "add", "-n", "Go 博客", "-a", "goblog", "-u", "https://go.dev/blog",
+		"--feed", "https://go.dev/blog/feed.atom", "--tag", "go,backend", "--tag", "Go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "成功添加订阅：Go 博客") || !strings.Contains(out, "标签: go, backend") {
+		t.Errorf("add output:\n%s", out)
+	}
+
+	if _, err := executeCommand(t, "add", "-n", "Rust", "-u", "https://blog.rust-lang.org", "--no-discover", "--tag", "rust"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executeCommand(t, "add", "-n", "goblog", "-u", "https://example.com", "--no-discover"); err == nil {
+		t.Error("add accepted a name used as another subscription's alias")
+	}
+
+	subs := listSubscriptions(t)
+	if len(subs) != 2 || subs[0].Name != "Go 博客" || subs[1].Name != "Rust" {
+		t.Fatalf("list = %+v", subs)
+	}
+	if subs[0].FeedURL != "https://go.dev/blog/feed.atom" || strings.Join(subs[0].Tags, ",") != "go,backend" {
+		t.Errorf("first subscription = %+v", subs[0])
+	}
+	if len(subs[1].Tags) != 1 {
+		t.Errorf("tags leaked between add invocations: %q", subs[1].Tags)
+	}
+
+	if subs := listSubscriptions(t, "--tag", "rust"); len(subs) != 1 || subs[0].Name != "Rust" {
+		t.Errorf("list --tag rust = %+v", subs)
+	}
+
+	if _, err := executeCommand(t, "edit", "goblog", "--name", "Go Blog"); err != nil {
+		t.Fatal(err)
+	}
+	config, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Subscriptions[0].Name != "Go Blog" || config.Subscriptions[0].Alias != "goblog" {
+		t.Errorf("after edit: %+v", config.Subscriptions[0])
+	}
+}
+
+func TestFetchRecordsToHistoryStore(t *testing.T) {
+	_, historyPath := useMemoryStorage(t)
+
+	feed, err := os.ReadFile(filepath.Join("..", "internal", "official", "testdata", "rss2.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write(feed)
+	}))
+	defer server.Close()
+
+	if _, err := executeCommand(t, "add", "-n", "测试订阅源", "-u", server.URL, "--feed", server.URL+"/feed"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executeCommand(t, "fetch", "测试订阅源", "-o", "json"); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := history.NewFile(historyPath).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Items) == 0 || h.Items[0].Source != "测试订阅源" {
+		t.Errorf("history not recorded in the configured store: %+v", h.Items)
+	}
+
+	// 第二次只看新内容时全部已见过
+	out, err := executeCommand(t, "fetch", "测试订阅源", "--new", "-o", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sets []struct {
+		Results    []any `json:"results"`
+		KnownCount int   `json:"known_count"`
+	}
+	if err := json.Unmarshal([]byte(out), &sets); err != nil {
+		t.Fatalf("fetch output is not JSON: %v\n%s", err, out)
+	}
+	if len(sets) != 1 || len(sets[0].Results) != 0 || sets[0].KnownCount != len(h.Items) {
+		t.Errorf("fetch --new = %s", out)
+	}
+}
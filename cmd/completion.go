@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"news4coder/internal/official"
+	"news4coder/internal/storage"
+	"news4coder/internal/subscription"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// completionFunc cobra 参数补全函数
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// loadSubscriptionsForCompletion 读取订阅列表用于补全，出错时返回空列表
+//
+// 补全在用户每次按 Tab 时执行，不能产生副作用：默认的文件存储使用 storage.ReadConfig
+// 只读加载，不加锁（不会被其他命令阻塞）、不创建目录，也不写回配置升级结果。
+func loadSubscriptionsForCompletion() []subscription.Subscription {
+	// 补全时不执行 PersistentPreRun，需要在这里应用 --config
+	storage.SetConfigPath(configPath)
+
+	var config *subscription.Config
+	if storageOverride != nil {
+		loaded, err := storageOverride.Load()
+		if err != nil {
+			return nil
+		}
+		config = loaded
+	} else {
+		path, err := storage.ConfigPath()
+		if err != nil {
+			return nil
+		}
+		if config, err = storage.ReadConfig(path); err != nil {
+			return nil
+		}
+	}
+	return newManager(config).List()
+}
+
+// subscriptionCandidates 返回订阅名称和别名的补全候选（带说明），跳过 exclude 中已填写的值
+func subscriptionCandidates(toComplete string, exclude []string) []string {
+	var candidates []string
+	for _, sub := range loadSubscriptionsForCompletion() {
+		if sub.Alias != "" {
+			candidates = appendCandidate(candidates, sub.Alias, sub.Name, toComplete, exclude)
+		}
+		candidates = appendCandidate(candidates, sub.Name, sub.URL, toComplete, exclude)
+	}
+	return candidates
+}
+
+// officialCandidates 返回启用的官方源别名的补全候选（带说明）
+func officialCandidates(toComplete string, exclude []string) []string {
+	var candidates []string
+	for _, source := range official.GetRegistry().List() {
+		candidates = appendCandidate(candidates, source.Alias, "🎯 "+source.Name, toComplete, exclude)
+	}
+	return candidates
+}
+
+// appendCandidate 添加以 toComplete 开头且未被排除的候选项，格式为 "值\t说明"
+func appendCandidate(candidates []string, value, description, toComplete string, exclude []string) []string {
+	if !strings.HasPrefix(strings.ToLower(value), strings.ToLower(toComplete)) {
+		return candidates
+	}
+	for _, e := range exclude {
+		if e == value {
+			return candidates
+		}
+	}
+	return append(candidates, value+"\t"+description)
+}
+
+// completeSubscriptions 补全订阅名称或别名
+func completeSubscriptions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return subscriptionCandidates(toComplete, nil), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFetchTargets 补全 fetch 的信息源：官方源别名与订阅名称或别名，已填写的不再提示
+func completeFetchTargets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	exclude := append(append([]string{}, fetchNames...), args...)
+	candidates := officialCandidates(toComplete, exclude)
+	candidates = append(candidates, subscriptionCandidates(toComplete, exclude)...)
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSingleSubscription 补全只接受一个订阅的位置参数
+func completeSingleSubscription(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeSubscriptions(cmd, args, toComplete)
+}
+
+// completeTags 补全订阅中已使用的标签
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	seen := make(map[string]bool)
+	var candidates []string
+	for _, sub := range loadSubscriptionsForCompletion() {
+		for _, tag := range sub.Tags {
+			if !seen[tag] && strings.HasPrefix(strings.ToLower(tag), strings.ToLower(toComplete)) {
+				seen[tag] = true
+				candidates = append(candidates, tag)
+			}
+		}
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeValues 返回补全固定取值的函数
+func completeValues(values ...string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// registerFlagCompletion 为参数注册补全函数，参数不存在属于编码错误
+func registerFlagCompletion(cmd *cobra.Command, flag string, fn completionFunc) {
+	if err := cmd.RegisterFlagCompletionFunc(flag, fn); err != nil {
+		panic(err)
+	}
+}
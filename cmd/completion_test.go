@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"news4coder/internal/storage"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// useConfigForCompletion 让补全读取指定的配置文件，测试结束后恢复
+func useConfigForCompletion(t *testing.T, path string) {
+	t.Helper()
+	previous := configPath
+	configPath = path
+	t.Cleanup(func() {
+		configPath = previous
+		storage.SetConfigPath(previous)
+	})
+}
+
+func TestCompletionHasNoSideEffects(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "subscriptions.json")
+	// 版本 1 的配置文件：补全只在内存中升级，不写回也不生成 .v1.bak
+	legacy := `{"subscriptions": [{"name": "Go 博客", "alias": "go", "url": "https://go.dev/blog", "tags": ["golang"]}]}`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	useConfigForCompletion(t, path)
+
+	// 其他命令持有文件锁时补全也不会阻塞
+	done := make(chan []string, 1)
+	err := storage.WithLock(path, func() error {
+		go func() { done <- subscriptionCandidates("", nil) }()
+		select {
+		case candidates := <-done:
+			want := []string{"go\tGo 博客", "Go 博客\thttps://go.dev/blog"}
+			if len(candidates) != len(want) || candidates[0] != want[0] || candidates[1] != want[1] {
+				t.Errorf("candidates = %q, want %q", candidates, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("completion blocked on the config lock")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != legacy {
+		t.Errorf("completion modified the config file:\n%s", data)
+	}
+	if _, err := os.Stat(path + ".v1.bak"); !os.IsNotExist(err) {
+		t.Error("completion wrote a migration backup")
+	}
+
+	// 配置目录不存在时不创建
+	missing := filepath.Join(dir, "missing")
+	useConfigForCompletion(t, filepath.Join(missing, "subscriptions.json"))
+	if candidates, _ := completeTags(nil, nil, ""); len(candidates) != 0 {
+		t.Errorf("candidates = %q, want none", candidates)
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Error("completion created the config directory")
+	}
+}
+
+func TestCompletionIgnoresInvalidConfig(t *testing.T) {
+	tests := map[string]string{
+		"invalid json":  `{"subscriptions": [`,
+		"newer version": `{"version": 99, "subscriptions": [{"name": "新版本", "url": "https://example.com"}]}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "subscriptions.json")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			useConfigForCompletion(t, path)
+
+			if candidates, _ := completeSubscriptions(nil, nil, ""); len(candidates) != 0 {
+				t.Errorf("candidates = %q, want none", candidates)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"news4coder/internal/history"
+	"news4coder/internal/search"
+	"news4coder/internal/subscription"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editName     string
+	editAlias    string
+	editURL      string
+	editFeedURL  string
+	editDiscover bool
+	editBackend  string
+	editCategory string
+	editTags     []string
+)
+
+// editFlagNames edit 命令中表示修改内容的参数，不包括 --config 等全局参数
+var editFlagNames = []string{"name", "alias", "url", "feed", "discover", "backend", "category", "tag"}
+
+var editCmd = &cobra.Command{
+	Use:   "edit <名称或别名>",
+	Short: "修改订阅",
+	Long: `修改已有订阅的名称、别名、URL 或其他设置，未指定的字段保持不变。
+
+传入空字符串可以清除可选字段，例如 --alias "" 删除别名、--feed "" 改回站内搜索。
+修改遵循与 add 相同的校验规则，名称和别名不能与其他订阅重复，创建时间保持不变。`,
+	Example: `  news4coder edit hn --name "Hacker News 首页"
+  news4coder edit "Hacker News" --alias hn2
+  news4coder edit goblog --url "https://go.dev/blog" --discover
+  news4coder edit tech --backend bing --category 博客
+  news4coder edit tech --feed ""
+  news4coder edit webdev --tag frontend,perf`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		if !anyFlagChanged(cmd, editFlagNames) {
+			return fmt.Errorf("请至少指定一项要修改的内容")
+		}
+		if editDiscover && flags.Changed("feed") {
+			return fmt.Errorf("不能同时指定 --feed 和 --discover")
+		}
+
+		// 创建存储实例
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("初始化存储失败: %w", err)
+		}
+
+		// 自动发现订阅源（在加锁之前完成，避免网络请求期间阻塞其他命令）
+		var discoverURL, discoveredFeed string
+		if editDiscover {
+			config, err := store.Load()
+			if err != nil {
+				return fmt.Errorf("加载配置失败: %w", err)
+			}
+			existing, err := newManager(config).Get(args[0])
+			if err != nil {
+				return err
+			}
+			discoverURL = existing.URL
+			if flags.Changed("url") {
+				discoverURL = editURL
+			}
+			discoveredFeed = discoverFeed(cmd.Context(), discoverURL)
+		}
+
+		var current, sub subscription.Subscription
+		err = store.Update(func(config *subscription.Config) error {
+			manager := newManager(config)
+			existing, err := manager.Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			// 只修改指定的字段
+			current, sub = *existing, *existing
+			if flags.Changed("name") {
+				sub.Name = editName
+			}
+			if flags.Changed("alias") {
+				sub.Alias = editAlias
+			}
+			if flags.Changed("url") {
+				sub.URL = editURL
+			}
+			if flags.Changed("feed") {
+				sub.FeedURL = editFeedURL
+			}
+			if flags.Changed("backend") {
+				sub.Backend = editBackend
+			}
+			if flags.Changed("category") {
+				sub.Category = editCategory
+			}
+			if flags.Changed("tag") {
+				sub.Tags = editTags
+			}
+			if editDiscover {
+				// 发现期间其他命令修改了网站地址时，发现结果已不对应当前地址
+				if sub.URL != discoverURL {
+					return fmt.Errorf("订阅 %s 的网站地址在发现订阅源期间被修改，请重试", args[0])
+				}
+				sub.FeedURL = discoveredFeed
+			}
+
+			updated, err := manager.Update(args[0], sub)
+			if err != nil {
+				return err
+			}
+			sub = *updated
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// 输出修改结果
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s 成功修改订阅：%s\n", green("✓"), sub.Name)
+		printChange("名称", current.Name, sub.Name)
+		printChange("别名", current.Alias, sub.Alias)
+		printChange("URL", current.URL, sub.URL)
+		printChange("订阅源", current.FeedURL, sub.FeedURL)
+		printChange("搜索后端", current.Backend, sub.Backend)
+		printChange("分类", current.Category, sub.Category)
+		printChange("标签", strings.Join(current.Tags, ", "), strings.Join(sub.Tags, ", "))
+
+		// 历史记录按订阅名称保存，改名后随之迁移，避免 --new 把已见过的内容当作新内容
+		if current.Name != sub.Name {
+			renameHistory(current.Name, sub.Name)
+		}
+
+		return nil
+	},
+}
+
+// renameHistory 将历史记录中的信息源 from 改为 to，失败时仅给出提示
+func renameHistory(from, to string) {
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	store, err := openHistory()
+	if err == nil {
+		// 没有该订阅的历史记录时不写入文件
+		if h, loadErr := store.Load(); loadErr == nil && h.RenameSource(from, to) == 0 {
+			return
+		}
+		err = store.Update(func(h *history.History) error {
+			h.RenameSource(from, to)
+			return nil
+		})
+	}
+	if err != nil {
+		fmt.Printf("%s 历史记录迁移失败，fetch --new 会将 %s 的已有内容视为新内容: %v\n", yellow("!"), to, err)
+	}
+}
+
+// anyFlagChanged 判断指定参数中是否有在命令行中设置过的
+func anyFlagChanged(cmd *cobra.Command, names []string) bool {
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// printChange 显示发生变化的字段
+func printChange(label, before, after string) {
+	if before == after {
+		return
+	}
+	gray := color.New(color.FgHiBlack).SprintFunc()
+	if before == "" {
+		before = "-"
+	}
+	if after == "" {
+		after = "-"
+	}
+	fmt.Printf("  %s: %s → %s\n", label, gray(before), after)
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+	editCmd.Flags().StringVarP(&editName, "name", "n", "", "新的订阅名称")
+	editCmd.Flags().StringVarP(&editAlias, "alias", "a", "", "新的别名（空字符串表示删除）")
+	editCmd.Flags().StringVarP(&editURL, "url", "u", "", "新的网站URL")
+	editCmd.Flags().StringVar(&editFeedURL, "feed", "", "新的 RSS/Atom 订阅源地址（空字符串表示改用站内搜索）")
+	editCmd.Flags().BoolVar(&editDiscover, "discover", false, "重新自动发现订阅源")
+	editCmd.Flags().StringVar(&editBackend, "backend", "", "站内搜索后端: duckduckgo, bing, searxng（空字符串表示使用全局设置）")
+	editCmd.Flags().StringVar(&editCategory, "category", "", "订阅分类（空字符串表示删除）")
+	editCmd.Flags().StringSliceVar(&editTags, "tag", nil, "替换订阅标签（可重复指定或用逗号分隔，空字符串表示清空）")
+
+	editCmd.ValidArgsFunction = completeSingleSubscription
+	registerFlagCompletion(editCmd, "backend", completeValues(search.BackendNames()...))
+}
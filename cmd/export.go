@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"news4coder/internal/subscription"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var exportFile string
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "导出订阅供其他阅读器使用",
+	Long:  `将订阅导出为其他阅读器可以导入的文件格式。`,
+}
+
+var exportOPMLCmd = &cobra.Command{
+	Use:   "opml",
+	Short: "导出订阅为 OPML",
+	Long: `将全部订阅导出为 OPML 2.0 文档。
+
+有分类的订阅放在以分类命名的文件夹中，分类和标签写入 category 属性；
+别名和搜索后端以 alias、backend 属性保存。重新导入 news4coder 时这些信息
+都会被还原。默认输出到标准输出。`,
+	Example: `  news4coder export opml > feeds.opml
+  news4coder export opml --file feeds.opml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// 创建存储实例
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("初始化存储失败: %w", err)
+		}
+
+		// 加载配置
+		config, err := store.Load()
+		if err != nil {
+			return fmt.Errorf("加载配置失败: %w", err)
+		}
+
+		subs := newManager(config).List()
+
+		if exportFile == "" {
+			return subscription.WriteOPML(os.Stdout, subs)
+		}
+
+		file, err := os.Create(exportFile)
+		if err != nil {
+			return fmt.Errorf("创建文件失败: %w", err)
+		}
+		if err := subscription.WriteOPML(file, subs); err != nil {
+			file.Close()
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("写入文件失败: %w", err)
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s 已导出 %d 个订阅到 %s\n", green("✓"), len(subs), exportFile)
+		return nil
+	},
+}
+
+func init() {
+	exportOPMLCmd.Flags().StringVarP(&exportFile, "file", "f", "", "输出文件（默认输出到标准输出）")
+	exportCmd.AddCommand(exportOPMLCmd)
+	rootCmd.AddCommand(exportCmd)
+}
@@ -1,91 +1,187 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"news4coder/internal/history"
 	"news4coder/internal/official"
 	"news4coder/internal/search"
-	"news4coder/internal/storage"
 	"news4coder/internal/subscription"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var (
-	fetchName string
-	demoMode  bool
+	fetchNames       []string
+	fetchAll         bool
+	fetchTags        []string
+	fetchOnlyNew     bool
+	demoMode         bool
+	fetchBackend     string
+	fetchSearXNGURL  string
+	fetchPageDates   bool
+	fetchFormat      string
+	fetchTemplate    string
+	fetchConcurrency int
+	fetchTimeout     time.Duration
+	fetchDeadline    time.Duration
 )
 
 var fetchCmd = &cobra.Command{
-	Use:   "fetch",
+	Use:   "fetch [名称或别名...]",
 	Short: "获取订阅的最新内容",
 	Long: `获取指定订阅源的最新内容。
 
+可以通过 --name（可重复）或位置参数指定多个信息源，使用 --tag 获取带有该标签的
+全部订阅，或使用 --all 获取全部用户订阅和官方信息源。多个信息源会并发获取，
+结果按信息源分组显示，失败的信息源在最后统一报告，不会中断其他信息源。
+
 专注模式：官方信息源（如 infoq）使用专用抓取器，直接获取原站热点内容。
-普通模式：其他订阅源使用 DuckDuckGo 站内搜索获取内容。`,
+订阅源模式：添加时发现了 RSS/Atom 订阅源的订阅，直接读取订阅源。
+普通模式：其他订阅源使用站内搜索获取内容。
+
+搜索后端（duckduckgo、bing、searxng）按以下优先级选择：
+  1. --backend 参数
+  2. 订阅的 backend 设置（add --backend）
+  3. 环境变量 NEWS4CODER_BACKEND
+  4. 默认 duckduckgo
+
+使用 searxng 时需通过 --searxng-url 或环境变量 NEWS4CODER_SEARXNG_URL 指定实例地址。
+` + outputSchemaHelp + "\n" + templateHelp,
 	Example: `  # 专注模式 - 官方信息源
   news4coder fetch -n infoq
   
   # 普通模式 - 站内搜索
   news4coder fetch -n hn
   news4coder fetch --name "Hacker News"
+  news4coder fetch -n hn --backend bing
+  news4coder fetch -n hn --backend searxng --searxng-url https://searx.example.org
   
+  # 只看上次之后的新内容
+  news4coder fetch -n infoq --new
+  news4coder fetch --all --new
+
+  # 批量获取
+  news4coder fetch infoq hn goblog
+  news4coder fetch --all
+  news4coder fetch --all --concurrency 8 --timeout 20s --deadline 90s
+
+  # 按标签获取
+  news4coder fetch --tag backend
+  news4coder fetch --tag frontend --tag security
+
+  # 机器可读输出
+  news4coder fetch --all -o json
+  news4coder fetch -n hn -o csv > hn.csv
+
+  # 自定义模板
+  news4coder fetch --all --format '{{.Source}}\t{{.Title | truncate 40}}\t{{.URL | host}}'
+  news4coder fetch -n hn --template digest.tmpl
+
   # 演示模式
   news4coder fetch -n infoq --demo`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if fetchName == "" {
-			return fmt.Errorf("请指定订阅名称（--name）")
+		if err := validateOutputFormat(); err != nil {
+			return err
+		}
+		if err := loadResultTemplate(fetchFormat, fetchTemplate); err != nil {
+			return err
+		}
+		// --backend 对所有信息源生效，获取前统一校验，避免每个信息源重复报告同一错误
+		if fetchBackend != "" {
+			if _, err := search.NewBackend(fetchBackend, searchBackendOptions()); err != nil {
+				return err
+			}
+		}
+
+		names := append(append([]string{}, fetchNames...), args...)
+
+		if fetchAll || len(fetchTags) > 0 || len(names) > 1 {
+			targets, err := resolveFetchTargets(names, fetchTags, fetchAll)
+			if err != nil {
+				return err
+			}
+			return runBatchFetch(cmd.Context(), targets, demoMode)
+		}
+
+		if len(names) == 0 {
+			return fmt.Errorf("请指定订阅名称（--name）、标签（--tag）或使用 --all 获取全部信息源")
 		}
 
 		// 首先检查是否为官方信息源（专注模式）
 		registry := official.GetRegistry()
-		if source, exists := registry.Get(fetchName); exists {
-			return fetchOfficialSource(source)
+		if source, exists := registry.Get(names[0]); exists {
+			return runOfficialSource(cmd.Context(), source, demoMode, 0)
 		}
 
 		// 普通模式：从订阅列表中查找
-		return fetchUserSubscription()
+		return runUserSubscription(cmd.Context(), names[0])
 	},
 }
 
-// fetchOfficialSource 专注模式：获取官方信息源内容
-func fetchOfficialSource(source *official.Source) error {
+// runOfficialSource 获取并显示官方信息源内容，limit 大于 0 时最多显示 limit 条
+func runOfficialSource(ctx context.Context, source *official.Source, demo bool, limit int) error {
 	cyan := color.New(color.FgCyan).SprintFunc()
 	magenta := color.New(color.FgMagenta, color.Bold).SprintFunc()
 
-	fmt.Printf("%s %s 专注模式 - 正在获取 %s 的热点内容...\n", magenta("🎯"), cyan("⟳"), source.Name)
-	fmt.Println()
+	fmt.Fprintf(statusOut(), "%s %s 专注模式 - 正在获取 %s 的热点内容...\n\n", magenta("🎯"), cyan("⟳"), source.Name)
+
+	set, err := fetchOfficialSource(ctx, source, demo)
+	if err != nil {
+		return err
+	}
+
+	// 先截断再记录历史，未显示的条目下次仍视为新内容
+	if limit > 0 && len(set.Results) > limit {
+		set.Results = set.Results[:limit]
+	}
+
+	set = recordHistory(fetchOnlyNew, set)[0]
+	return showResultSets(set)
+}
+
+// fetchOfficialSource 专注模式：获取官方信息源内容
+func fetchOfficialSource(ctx context.Context, source *official.Source, demo bool) (search.ResultSet, error) {
+	set := search.ResultSet{
+		Source:    source.Name,
+		SourceURL: source.URL,
+		Mode:      search.ModeOfficial,
+	}
 
-	if demoMode {
+	if demo {
 		// 演示模式
-		results := generateDemoResults(source.Name, source.URL)
-		displayOfficialResults(results, source.Name, source.URL)
-		return nil
+		set.Provenance = search.NewDemoProvenance()
+		set.Results = generateDemoResults(source.Name, source.URL)
+		return set, nil
 	}
 
 	// 创建专用抓取器
 	factory := official.NewFetcherFactory()
 	fetcher, err := factory.Create(source)
 	if err != nil {
-		return fmt.Errorf("创建抓取器失败: %w", err)
+		return set, fmt.Errorf("创建抓取器失败: %w", err)
 	}
 
 	// 执行抓取
-	results, err := fetcher.Fetch()
+	results, err := fetcher.Fetch(ctx)
 	if err != nil {
-		return fmt.Errorf("获取内容失败: %w", err)
+		return set, fmt.Errorf("获取内容失败: %w", err)
 	}
 
-	// 显示结果
-	displayOfficialResults(results, source.Name, source.URL)
-	return nil
+	set.Provenance = search.NewLiveProvenance(source.FetcherType)
+	set.Results = results
+	return set, nil
 }
 
-// fetchUserSubscription 普通模式：获取用户订阅的内容
-func fetchUserSubscription() error {
+// runUserSubscription 获取并显示用户订阅的内容
+func runUserSubscription(ctx context.Context, nameOrAlias string) error {
 	// 创建存储实例
-	store, err := storage.New()
+	store, err := openStorage()
 	if err != nil {
 		return fmt.Errorf("初始化存储失败: %w", err)
 	}
@@ -97,80 +193,191 @@ func fetchUserSubscription() error {
 	}
 
 	// 创建订阅管理器
-	manager := subscription.NewManager(config)
+	manager := newManager(config)
 
 	// 获取订阅信息
-	sub, err := manager.Get(fetchName)
+	sub, err := manager.Get(nameOrAlias)
 	if err != nil {
 		return err
 	}
 
 	// 显示提示信息
 	cyan := color.New(color.FgCyan).SprintFunc()
-	fmt.Printf("%s 普通模式 - 正在搜索 %s 的最新内容...\n", cyan("⟳"), sub.Name)
-	fmt.Println()
+	if sub.FeedURL != "" && !demoMode {
+		fmt.Fprintf(statusOut(), "%s 订阅源模式 - 正在读取 %s 的订阅源...\n\n", cyan("⟳"), sub.Name)
+	} else {
+		fmt.Fprintf(statusOut(), "%s 普通模式 - 正在搜索 %s 的最新内容...\n\n", cyan("⟳"), sub.Name)
+	}
 
-	// 执行搜索
-	var results []search.SearchResult
+	set, err := fetchUserSubscription(ctx, sub, demoMode)
+	if err != nil {
+		return err
+	}
+
+	set = recordHistory(fetchOnlyNew, set)[0]
+	return showResultSets(set)
+}
 
-	if demoMode {
+// fetchUserSubscription 获取用户订阅的内容：有订阅源时读取订阅源，否则使用站内搜索
+func fetchUserSubscription(ctx context.Context, sub *subscription.Subscription, demo bool) (search.ResultSet, error) {
+	set := search.ResultSet{
+		Source:    sub.Name,
+		SourceURL: sub.URL,
+		Mode:      search.ModeSearch,
+	}
+
+	if demo {
 		// 演示模式
-		results = generateDemoResults(sub.Name, sub.URL)
-	} else {
-		// 创建搜索引擎
-		engine := search.NewEngine()
-		var searchErr error
-		results, searchErr = engine.Search(sub.URL)
-		if searchErr != nil {
-			return fmt.Errorf("搜索失败: %w", searchErr)
+		set.Provenance = search.NewDemoProvenance()
+		set.Results = generateDemoResults(sub.Name, sub.URL)
+		return set, nil
+	}
+
+	// 订阅源模式：已保存 RSS/Atom 地址时直接读取
+	if sub.FeedURL != "" {
+		results, err := official.NewRSSFetcher(sub.FeedURL).Fetch(ctx)
+		if err != nil {
+			return set, fmt.Errorf("读取订阅源失败: %w", err)
 		}
+
+		set.Mode = search.ModeFeed
+		set.SourceURL = sub.FeedURL
+		set.Provenance = search.NewLiveProvenance("rss")
+		set.Results = results
+		return set, nil
 	}
 
-	// 显示结果
-	displayResults(results, sub.Name)
-	return nil
+	// 创建搜索引擎
+	engine, err := newSearchEngine(sub)
+	if err != nil {
+		return set, err
+	}
+
+	// 执行搜索
+	results, err := engine.Search(ctx, sub.URL)
+	if err != nil {
+		return set, fmt.Errorf("搜索失败: %w", err)
+	}
+
+	// 抓取原文页面补全发布时间
+	if fetchPageDates {
+		search.FillPageDates(ctx, results)
+	}
+
+	set.Provenance = search.NewLiveProvenance(engine.Backend().Name())
+	set.Results = results
+	return set, nil
 }
 
-// displayOfficialResults 显示官方信息源结果（专注模式）
-func displayOfficialResults(results []search.SearchResult, sourceName, sourceURL string) {
-	bold := color.New(color.Bold).SprintFunc()
-	green := color.New(color.FgGreen).SprintFunc()
-	magenta := color.New(color.FgMagenta).SprintFunc()
+// recordHistory 将实时获取的结果写入历史记录，演示数据不会记录；
+// onlyNew 为 true 时过滤掉此前见过的结果。历史记录失败时仅给出提示，不影响结果显示
+func recordHistory(onlyNew bool, sets ...search.ResultSet) []search.ResultSet {
+	hasLive := false
+	for _, set := range sets {
+		if set.Provenance.IsLive() {
+			hasLive = true
+		}
+	}
+	if !hasLive {
+		return sets
+	}
 
-	fmt.Println(bold(fmt.Sprintf("━━━ 🎯 %s 热点内容 ━━━", sourceName)))
-	fmt.Println()
+	yellow := color.New(color.FgYellow).SprintFunc()
 
-	for _, result := range results {
-		fmt.Printf("%s %s\n", green(fmt.Sprintf("%d.", result.Index)), bold(result.Title))
-		fmt.Printf("   🔗 %s\n", makeClickableURL(result.URL))
+	store, err := openHistory()
+	if err != nil {
+		fmt.Fprintf(statusOut(), "%s 历史记录不可用: %v\n", yellow("!"), err)
+		return sets
+	}
 
-		if result.Snippet != "" {
-			snippet := result.Snippet
-			if len(snippet) > 200 {
-				snippet = snippet[:200] + "..."
+	updated := make([]search.ResultSet, len(sets))
+	applied := false
+	err = store.Update(func(h *history.History) error {
+		for i, set := range sets {
+			if set.Provenance.IsLive() {
+				if onlyNew {
+					set.NewOnly = true
+					set.Results, set.KnownCount = h.Unseen(set.Source, set.Results)
+				}
+				h.Record(set.Source, sets[i].Results, set.Provenance.FetchedAt)
 			}
-			fmt.Printf("   %s\n", wrapText(snippet, 80, "   "))
+			updated[i] = set
 		}
-		fmt.Println()
+		applied = true
+		return nil
+	})
+	if err != nil && !applied {
+		fmt.Fprintf(statusOut(), "%s 历史记录不可用: %v\n", yellow("!"), err)
+		return sets
+	}
+	if err != nil {
+		fmt.Fprintf(statusOut(), "%s 历史记录保存失败: %v\n", yellow("!"), err)
 	}
 
-	fmt.Println(bold(fmt.Sprintf("━━━ 共 %d 条结果 ━━━", len(results))))
-	fmt.Println()
+	return updated
+}
 
-	fmt.Printf("%s 专注模式：直接获取官方源 %s\n", magenta("🎯"), makeClickableURL(sourceURL))
+// newSearchEngine 根据命令行参数、订阅设置和环境变量创建搜索引擎
+func newSearchEngine(sub *subscription.Subscription) (*search.Engine, error) {
+	name := fetchBackend
+	if name == "" {
+		name = sub.Backend
+	}
+	if name == "" {
+		name = os.Getenv("NEWS4CODER_BACKEND")
+	}
+
+	backend, err := search.NewBackend(name, searchBackendOptions())
+	if err != nil {
+		return nil, err
+	}
+	return search.NewEngineWithBackend(backend), nil
+}
+
+// searchBackendOptions 根据命令行参数和环境变量返回搜索后端选项
+func searchBackendOptions() search.BackendOptions {
+	searxngURL := fetchSearXNGURL
+	if searxngURL == "" {
+		searxngURL = os.Getenv("NEWS4CODER_SEARXNG_URL")
+	}
+	return search.BackendOptions{SearXNGURL: searxngURL}
 }
 
-// displayResults 格式化显示搜索结果（普通模式）
-func displayResults(results []search.SearchResult, sourceName string) {
+// showResultSets 按输出格式显示结果集
+func showResultSets(sets ...search.ResultSet) error {
+	if isMachineOutput() {
+		warnDemoData(os.Stderr, sets)
+		return writeResultSets(os.Stdout, sets)
+	}
+	for _, set := range sets {
+		displayResultSet(set)
+	}
+	return nil
+}
+
+// displayResultSet 格式化显示结果集
+func displayResultSet(set search.ResultSet) {
 	bold := color.New(color.Bold).SprintFunc()
 	green := color.New(color.FgGreen).SprintFunc()
+	gray := color.New(color.FgHiBlack).SprintFunc()
+
+	if set.Provenance.Synthetic {
+		printDemoBanner()
+	}
 
-	fmt.Println(bold(fmt.Sprintf("━━━ %s 最新内容 ━━━", sourceName)))
+	if set.Mode == search.ModeOfficial {
+		fmt.Println(bold(fmt.Sprintf("━━━ 🎯 %s 热点内容 ━━━", set.Source)))
+	} else {
+		fmt.Println(bold(fmt.Sprintf("━━━ %s 最新内容 ━━━", set.Source)))
+	}
 	fmt.Println()
 
-	for _, result := range results {
+	for _, result := range set.Results {
 		fmt.Printf("%s %s\n", green(fmt.Sprintf("%d.", result.Index)), bold(result.Title))
 		fmt.Printf("   🔗 %s\n", makeClickableURL(result.URL))
+		if !result.PublishedDate.IsZero() {
+			fmt.Printf("   🕒 %s\n", formatPublishedDate(result.PublishedDate))
+		}
 
 		if result.Snippet != "" {
 			snippet := result.Snippet
@@ -182,11 +389,85 @@ func displayResults(results []search.SearchResult, sourceName string) {
 		fmt.Println()
 	}
 
-	fmt.Println(bold(fmt.Sprintf("━━━ 共 %d 条结果 ━━━", len(results))))
+	fmt.Println(bold(fmt.Sprintf("━━━ 共 %d 条结果 ━━━", len(set.Results))))
 	fmt.Println()
 
-	gray := color.New(color.FgHiBlack).SprintFunc()
-	fmt.Println(gray("💡 普通模式：基于 DuckDuckGo 站内搜索"))
+	if set.NewOnly {
+		fmt.Println(gray(fmt.Sprintf("🆕 %d 条新内容，%d 条此前已见过（已隐藏）", len(set.Results), set.KnownCount)))
+	}
+
+	switch {
+	case set.Provenance.Synthetic:
+		fmt.Println(gray("💡 演示模式：使用模拟数据"))
+	case set.Mode == search.ModeOfficial:
+		magenta := color.New(color.FgMagenta).SprintFunc()
+		fmt.Printf("%s 专注模式：直接获取官方源 %s\n", magenta("🎯"), makeClickableURL(set.SourceURL))
+	case set.Mode == search.ModeFeed:
+		fmt.Println(gray(fmt.Sprintf("💡 订阅源模式：读取 %s", set.SourceURL)))
+	default:
+		fmt.Println(gray(fmt.Sprintf("💡 普通模式：基于 %s 站内搜索", set.Provenance.Backend)))
+	}
+	fmt.Println(gray(formatProvenance(set.Provenance)))
+
+	if set.Provenance.Synthetic {
+		fmt.Println()
+		printDemoBanner()
+	}
+}
+
+// formatProvenance 格式化数据来源说明
+func formatProvenance(p search.Provenance) string {
+	kind := map[string]string{
+		search.ProvenanceLive:   "实时获取",
+		search.ProvenanceCached: "本地缓存",
+		search.ProvenanceDemo:   "演示数据",
+	}[p.Kind]
+	if kind == "" {
+		kind = p.Kind
+	}
+	return fmt.Sprintf("📦 数据来源: %s · %s · 获取于 %s", kind, p.Backend, p.FetchedAt.Local().Format("2006-01-02 15:04:05"))
+}
+
+// printDemoBanner 显示演示数据警示横幅
+func printDemoBanner() {
+	banner := color.New(color.FgHiWhite, color.BgRed, color.Bold).SprintFunc()
+	fmt.Println(banner(" ⚠ 演示数据：以下内容为模拟生成，并非真实资讯 ⚠ "))
+	fmt.Println()
+}
+
+// warnDemoData 机器可读输出（含 --format 模板）中有演示数据时，在 w（通常为标准错误）显示警示，
+// 避免模拟数据被脚本当作真实资讯使用；数据本身通过 provenance.synthetic 字段标记
+func warnDemoData(w io.Writer, sets []search.ResultSet) {
+	var sources []string
+	for _, set := range sets {
+		if set.Provenance.Synthetic {
+			sources = append(sources, set.Source)
+		}
+	}
+	if len(sources) == 0 {
+		return
+	}
+	banner := color.New(color.FgHiWhite, color.BgRed, color.Bold).SprintFunc()
+	fmt.Fprintln(w, banner(" ⚠ 演示数据：以下内容为模拟生成，并非真实资讯 ⚠ "))
+	fmt.Fprintf(w, "  演示数据来源: %s\n", strings.Join(sources, "、"))
+}
+
+// formatPublishedDate 格式化发布时间，近期内容同时显示相对时间
+func formatPublishedDate(t time.Time) string {
+	local := t.Local()
+	elapsed := time.Since(t)
+	switch {
+	case elapsed < 0:
+		return local.Format("2006-01-02 15:04")
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%s（%d分钟前）", local.Format("2006-01-02 15:04"), int(elapsed.Minutes()))
+	case elapsed < 24*time.Hour:
+		return fmt.Sprintf("%s（%d小时前）", local.Format("2006-01-02 15:04"), int(elapsed.Hours()))
+	case elapsed < 30*24*time.Hour:
+		return fmt.Sprintf("%s（%d天前）", local.Format("2006-01-02 15:04"), int(elapsed.Hours()/24))
+	default:
+		return local.Format("2006-01-02")
+	}
 }
 
 // makeClickableURL 创建可点击的终端链接（使用 OSC 8 ANSI 转义序列）
@@ -297,7 +578,23 @@ func generateDemoResults(sourceName, sourceURL string) []search.SearchResult {
 
 func init() {
 	rootCmd.AddCommand(fetchCmd)
-	fetchCmd.Flags().StringVarP(&fetchName, "name", "n", "", "订阅名称（必填）")
+	fetchCmd.Flags().StringSliceVarP(&fetchNames, "name", "n", nil, "订阅名称或别名（可重复指定）")
+	fetchCmd.Flags().BoolVar(&fetchAll, "all", false, "获取全部用户订阅和官方信息源")
+	fetchCmd.Flags().StringSliceVar(&fetchTags, "tag", nil, "获取带有该标签的全部订阅（可重复指定）")
+	fetchCmd.Flags().BoolVar(&fetchOnlyNew, "new", false, "只显示此前未见过的内容")
 	fetchCmd.Flags().BoolVarP(&demoMode, "demo", "d", false, "演示模式（使用模拟数据）")
-	fetchCmd.MarkFlagRequired("name")
+	fetchCmd.Flags().StringVar(&fetchBackend, "backend", "", "站内搜索后端: duckduckgo, bing, searxng")
+	fetchCmd.Flags().StringVar(&fetchSearXNGURL, "searxng-url", "", "SearXNG 实例地址")
+	fetchCmd.Flags().BoolVar(&fetchPageDates, "page-dates", false, "抓取原文页面补全缺失的发布时间（较慢）")
+	addOutputFlag(fetchCmd)
+	fetchCmd.Flags().StringVar(&fetchFormat, "format", "", "自定义单条结果模板（Go text/template）")
+	fetchCmd.Flags().StringVar(&fetchTemplate, "template", "", "自定义模板文件，对每个信息源执行一次")
+	fetchCmd.Flags().IntVar(&fetchConcurrency, "concurrency", 4, "批量获取时的最大并发数")
+	fetchCmd.Flags().DurationVar(&fetchTimeout, "timeout", 30*time.Second, "批量获取时单个信息源的超时时间")
+	fetchCmd.Flags().DurationVar(&fetchDeadline, "deadline", 2*time.Minute, "批量获取的总时限")
+
+	fetchCmd.ValidArgsFunction = completeFetchTargets
+	registerFlagCompletion(fetchCmd, "name", completeFetchTargets)
+	registerFlagCompletion(fetchCmd, "tag", completeTags)
+	registerFlagCompletion(fetchCmd, "backend", completeValues(search.BackendNames()...))
 }
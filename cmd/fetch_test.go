@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"bytes"
+	"news4coder/internal/search"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWarnDemoData(t *testing.T) {
+	live := search.ResultSet{Source: "Go 博客", Provenance: search.Provenance{Kind: search.ProvenanceLive, FetchedAt: time.Now()}}
+	demo := search.ResultSet{Source: "InfoQ", Provenance: search.NewDemoProvenance()}
+
+	var b bytes.Buffer
+	warnDemoData(&b, []search.ResultSet{live})
+	if b.Len() != 0 {
+		t.Errorf("warning for live data: %q", b.String())
+	}
+
+	warnDemoData(&b, []search.ResultSet{live, demo})
+	out := b.String()
+	if !strings.Contains(out, "演示数据") || !strings.Contains(out, "InfoQ") {
+		t.Errorf("missing demo warning: %q", out)
+	}
+	if strings.Contains(out, "Go 博客") {
+		t.Errorf("warning lists live source: %q", out)
+	}
+}
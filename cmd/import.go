@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"news4coder/internal/subscription"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "从其他阅读器导入订阅",
+	Long:  `从其他阅读器导出的文件中批量导入订阅。`,
+}
+
+var importOPMLCmd = &cobra.Command{
+	Use:   "opml <文件>",
+	Short: "从 OPML 文件导入订阅",
+	Long: `从 OPML 文件导入订阅。
+
+每个带 xmlUrl 或 htmlUrl 的条目导入为一个订阅：text/title 作为名称，
+xmlUrl 作为订阅源地址，htmlUrl 作为网站地址（缺失时使用订阅源所在站点），
+category 属性或所在文件夹作为分类。news4coder 导出的 alias、backend 属性
+也会被还原。
+
+名称或别名与已有订阅重复、字段校验失败的条目会被跳过，并在最后列出原因。`,
+	Example: `  news4coder import opml feeds.opml`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("打开文件失败: %w", err)
+		}
+		defer file.Close()
+
+		subs, err := subscription.ParseOPML(file)
+		if err != nil {
+			return err
+		}
+
+		// 创建存储实例
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("初始化存储失败: %w", err)
+		}
+
+		// 逐条添加，重复或无效的条目跳过
+		var imported []subscription.Subscription
+		var skipped []string
+		err = store.Update(func(config *subscription.Config) error {
+			manager := newManager(config)
+			for _, sub := range subs {
+				if _, err := manager.Add(sub); err != nil {
+					name := sub.Name
+					if name == "" {
+						name = sub.URL
+					}
+					skipped = append(skipped, fmt.Sprintf("%s: %v", name, err))
+					continue
+				}
+				imported = append(imported, sub)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// 输出结果
+		green := color.New(color.FgGreen).SprintFunc()
+		yellow := color.New(color.FgYellow).SprintFunc()
+		for _, sub := range imported {
+			fmt.Printf("%s %s\n", green("✓"), sub.Name)
+		}
+		for _, reason := range skipped {
+			fmt.Printf("%s 跳过 %s\n", yellow("!"), reason)
+		}
+
+		fmt.Println()
+		fmt.Printf("共 %d 个条目：导入 %d 个，跳过 %d 个\n", len(subs), len(imported), len(skipped))
+		return nil
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importOPMLCmd)
+	rootCmd.AddCommand(importCmd)
+}
@@ -2,21 +2,30 @@ package cmd
 
 import (
 	"fmt"
-	"news4coder/internal/storage"
-	"news4coder/internal/subscription"
+	"os"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var listTag string
+
 var listCmd = &cobra.Command{
-	Use:     "list",
-	Short:   "列出所有订阅",
-	Long:    `显示所有已添加的订阅列表。`,
-	Example: `  news4coder list`,
+	Use:   "list",
+	Short: "列出所有订阅",
+	Long: `显示所有已添加的订阅列表，可以按标签筛选。
+` + outputSchemaHelp,
+	Example: `  news4coder list
+  news4coder list --tag frontend
+  news4coder list -o json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(); err != nil {
+			return err
+		}
+
 		// 创建存储实例
-		store, err := storage.New()
+		store, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("初始化存储失败: %w", err)
 		}
@@ -28,12 +37,23 @@ var listCmd = &cobra.Command{
 		}
 
 		// 创建订阅管理器
-		manager := subscription.NewManager(config)
+		manager := newManager(config)
 		subs := manager.List()
+		if listTag != "" {
+			subs = manager.ListByTag(listTag)
+		}
+
+		if isMachineOutput() {
+			return writeSubscriptions(os.Stdout, subs)
+		}
 
 		// 检查是否为空
 		if len(subs) == 0 {
 			yellow := color.New(color.FgYellow).SprintFunc()
+			if listTag != "" {
+				fmt.Printf("%s 没有带标签 %s 的订阅\n", yellow("!"), listTag)
+				return nil
+			}
 			fmt.Printf("%s 暂无订阅\n", yellow("!"))
 			fmt.Println("使用 'news4coder add --name <名称> --url <URL>' 添加订阅")
 			return nil
@@ -45,8 +65,8 @@ var listCmd = &cobra.Command{
 		fmt.Println()
 
 		// 表头
-		fmt.Printf("%-4s %-12s %-18s %-35s %-16s\n", "序号", "别名", "名称", "URL", "创建时间")
-		fmt.Println("───────────────────────────────────────────────────────────────────────────────────────────────")
+		fmt.Printf("%-4s %-12s %-18s %-35s %-16s %s\n", "序号", "别名", "名称", "URL", "创建时间", "标签")
+		fmt.Println("───────────────────────────────────────────────────────────────────────────────────────────────────────────")
 
 		// 表内容
 		for i, sub := range subs {
@@ -54,12 +74,13 @@ var listCmd = &cobra.Command{
 			if alias == "" {
 				alias = "-"
 			}
-			fmt.Printf("%-4d %-12s %-18s %-35s %-16s\n",
+			fmt.Printf("%-4d %-12s %-18s %-35s %-16s %s\n",
 				i+1,
 				truncateString(alias, 12),
 				truncateString(sub.Name, 18),
 				truncateString(sub.URL, 35),
-				sub.CreatedAt.Format("2006-01-02 15:04"))
+				sub.CreatedAt.Format("2006-01-02 15:04"),
+				strings.Join(sub.Tags, ","))
 		}
 
 		fmt.Println()
@@ -78,5 +99,8 @@ func truncateString(s string, maxLen int) string {
 }
 
 func init() {
+	listCmd.Flags().StringVar(&listTag, "tag", "", "只显示带有该标签的订阅")
+	registerFlagCompletion(listCmd, "tag", completeTags)
+	addOutputFlag(listCmd)
 	rootCmd.AddCommand(listCmd)
 }
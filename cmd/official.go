@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"news4coder/internal/official"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// reservedCommands cobra 在执行时自动添加的命令，官方源别名不能使用
+var reservedCommands = []string{"help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd}
+
+// addOfficialSourceCommands 为注册表中每个启用的官方源生成子命令，
+// 别名与已有命令冲突的官方源跳过并给出提示（仍可通过 fetch -n 获取）
+//
+// 命令在解析参数之前生成，调用前需先通过 configFlagFromArgs 应用 --config，
+// 以便读取对应配置目录下的 sources.json。
+//
+// sources.json 的加载警告在这里输出到标准错误：其中无效的官方源被跳过后不会生成命令，
+// 没有提示时用户只会看到“未知命令”。quiet 为 true 时（Shell 补全请求）不输出提示。
+func addOfficialSourceCommands(quiet bool) {
+	yellow := color.New(color.FgYellow).SprintFunc()
+	registry := official.GetRegistry()
+	if !quiet {
+		for _, warning := range registry.Warnings() {
+			fmt.Fprintf(os.Stderr, "%s %s\n", yellow("⚠"), warning)
+		}
+	}
+
+	for _, source := range registry.List() {
+		if commandExists(source.Alias) {
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "%s 官方源别名 %s 与已有命令重复，请使用 news4coder fetch -n %s\n", yellow("⚠"), source.Alias, source.Alias)
+			}
+			continue
+		}
+		rootCmd.AddCommand(newOfficialSourceCmd(source))
+	}
+}
+
+// commandExists 判断根命令下是否已有该名称或别名的命令
+func commandExists(name string) bool {
+	for _, reserved := range reservedCommands {
+		if name == reserved {
+			return true
+		}
+	}
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// newOfficialSourceCmd 创建获取指定官方源的子命令
+func newOfficialSourceCmd(source *official.Source) *cobra.Command {
+	long := fmt.Sprintf(`专注模式：直接从 %s 获取最新技术资讯。
+
+这是官方信息源，使用 %s 抓取器直接获取原站内容，
+无需搜索引擎中转，内容质量更高、更新更及时。
+
+页面地址: %s`, source.Name, source.FetcherType, source.URL)
+	if source.Description != "" {
+		long = source.Description + "\n\n" + long
+	}
+
+	// 每个命令使用独立的参数变量
+	var (
+		demo  bool
+		limit int
+	)
+
+	cmd := &cobra.Command{
+		Use:   source.Alias,
+		Short: "🎯 专注模式 - 获取 " + source.Name,
+		Long:  long + "\n" + outputSchemaHelp,
+		Example: fmt.Sprintf(`  news4coder %[1]s
+  news4coder %[1]s --limit 5
+  news4coder %[1]s -o json
+
+  # 演示模式
+  news4coder %[1]s --demo`, source.Alias),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputFormat(); err != nil {
+				return err
+			}
+			if limit < 0 {
+				return fmt.Errorf("--limit 不能为负数")
+			}
+			return runOfficialSource(cmd.Context(), source, demo, limit)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&demo, "demo", "d", false, "演示模式（使用模拟数据）")
+	cmd.Flags().IntVarP(&limit, "limit", "l", 0, "最多显示的条目数（0 表示不限制）")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+// configFlagFromArgs 在解析参数之前从命令行中找出 --config 的值，未指定时返回空字符串
+//
+// 支持 --config path 和 --config=path 两种写法，"--" 之后的参数不再查找。
+func configFlagFromArgs(args []string) string {
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return ""
+		case arg == "--config" && i+1 < len(args):
+			return args[i+1]
+		case strings.HasPrefix(arg, "--config="):
+			return strings.TrimPrefix(arg, "--config=")
+		}
+	}
+	return ""
+}
+
+// isCompletionRequest 判断命令行是否为 Shell 补全脚本发起的补全请求
+func isCompletionRequest(args []string) bool {
+	return len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"news4coder/internal/official"
+	"testing"
+)
+
+func TestOfficialSourceCmdFlagsIndependent(t *testing.T) {
+	first := newOfficialSourceCmd(&official.Source{Alias: "first", Name: "First", URL: "https://first.example.com", FetcherType: "rss"})
+	second := newOfficialSourceCmd(&official.Source{Alias: "second", Name: "Second", URL: "https://second.example.com", FetcherType: "rss"})
+
+	if err := first.ParseFlags([]string{"--demo", "--limit", "3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if demo, _ := first.Flags().GetBool("demo"); !demo {
+		t.Error("first: --demo not set")
+	}
+	if limit, _ := first.Flags().GetInt("limit"); limit != 3 {
+		t.Errorf("first: --limit = %d, want 3", limit)
+	}
+	if demo, _ := second.Flags().GetBool("demo"); demo {
+		t.Error("second: --demo leaked from first command")
+	}
+	if limit, _ := second.Flags().GetInt("limit"); limit != 0 {
+		t.Errorf("second: --limit = %d leaked from first command, want 0", limit)
+	}
+}
+
+func TestConfigFlagFromArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"none", []string{"infoq", "-l", "5"}, ""},
+		{"separate value", []string{"--config", "/tmp/a.json", "infoq"}, "/tmp/a.json"},
+		{"equals", []string{"list", "--config=/tmp/b.json"}, "/tmp/b.json"},
+		{"after subcommand", []string{"infoq", "--config", "c.json"}, "c.json"},
+		{"completion request", []string{"__complete", "--config", "d.json", "fetch", ""}, "d.json"},
+		{"missing value", []string{"infoq", "--config"}, ""},
+		{"after terminator", []string{"add", "--", "--config", "e.json"}, ""},
+		{"other flag", []string{"--configure", "x"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := configFlagFromArgs(tt.args); got != tt.want {
+				t.Errorf("configFlagFromArgs(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCompletionRequest(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{nil, false},
+		{[]string{"infoq"}, false},
+		{[]string{"completion", "bash"}, false},
+		{[]string{"__complete", "fetch", ""}, true},
+		{[]string{"__completeNoDesc", "fetch", ""}, true},
+	}
+	for _, tt := range tests {
+		if got := isCompletionRequest(tt.args); got != tt.want {
+			t.Errorf("isCompletionRequest(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,440 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"news4coder/internal/official"
+	"news4coder/internal/search"
+	"news4coder/internal/subscription"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// 支持的输出格式
+const (
+	outputText     = "text"
+	outputJSON     = "json"
+	outputNDJSON   = "ndjson"
+	outputCSV      = "csv"
+	outputMarkdown = "markdown"
+	outputYAML     = "yaml"
+)
+
+var outputFormats = []string{outputText, outputJSON, outputNDJSON, outputCSV, outputMarkdown, outputYAML}
+
+// outputFormat 当前命令的输出格式
+var outputFormat string
+
+// outputSchemaHelp 机器可读格式的字段说明，附加在命令帮助中
+const outputSchemaHelp = `
+输出格式（--output）：
+  text      彩色终端文本（默认）
+  json      JSON 数组
+  ndjson    每行一个 JSON 对象
+  csv       带表头的 CSV
+  markdown  Markdown 文本
+  yaml      YAML 列表
+各格式的字段名与 JSON 字段一致，详见 README 的“输出格式”一节。`
+
+// addOutputFlag 为命令添加 --output 参数
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", outputText, "输出格式: "+strings.Join(outputFormats, ", "))
+	registerFlagCompletion(cmd, "output", completeValues(outputFormats...))
+}
+
+// validateOutputFormat 校验输出格式
+func validateOutputFormat() error {
+	for _, f := range outputFormats {
+		if outputFormat == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("不支持的输出格式: %s（可选: %s）", outputFormat, strings.Join(outputFormats, ", "))
+}
+
+// isMachineOutput 判断是否为机器可读输出，此时不输出进度提示等文本
+func isMachineOutput() bool {
+	return outputFormat != "" && outputFormat != outputText
+}
+
+// statusOut 返回进度提示和警告的输出位置：机器可读输出时写入标准错误，避免污染数据
+func statusOut() io.Writer {
+	if isMachineOutput() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// resultRecord fetch 结果的扁平记录（ndjson、csv 每条结果一行）
+type resultRecord struct {
+	Source     string            `json:"source"`
+	SourceURL  string            `json:"source_url"`
+	Mode       string            `json:"mode"`
+	Provenance search.Provenance `json:"provenance"`
+	search.SearchResult
+}
+
+// flattenResultSets 将结果集展开为扁平记录
+func flattenResultSets(sets []search.ResultSet) []resultRecord {
+	records := []resultRecord{}
+	for _, set := range sets {
+		for _, result := range set.Results {
+			records = append(records, resultRecord{
+				Source:       set.Source,
+				SourceURL:    set.SourceURL,
+				Mode:         set.Mode,
+				Provenance:   set.Provenance,
+				SearchResult: result,
+			})
+		}
+	}
+	return records
+}
+
+// writeResultSets 按输出格式写出 fetch 结果
+func writeResultSets(w io.Writer, sets []search.ResultSet) error {
+	if sets == nil {
+		sets = []search.ResultSet{}
+	}
+
+	switch outputFormat {
+	case outputJSON:
+		return writeJSON(w, sets)
+	case outputNDJSON:
+		return writeNDJSON(w, flattenResultSets(sets))
+	case outputYAML:
+		return writeYAML(w, sets)
+	case outputCSV:
+		header := []string{"source", "source_url", "mode", "provenance_kind", "provenance_backend", "provenance_fetched_at", "provenance_synthetic", "index", "title", "url", "snippet", "published_date"}
+		var rows [][]string
+		for _, r := range flattenResultSets(sets) {
+			rows = append(rows, []string{
+				r.Source, r.SourceURL, r.Mode,
+				r.Provenance.Kind, r.Provenance.Backend, formatTimeField(r.Provenance.FetchedAt), strconv.FormatBool(r.Provenance.Synthetic),
+				strconv.Itoa(r.Index), r.Title, r.URL, r.Snippet, formatTimeField(r.PublishedDate),
+			})
+		}
+		return writeCSV(w, header, rows)
+	case outputMarkdown:
+		return writeResultSetsMarkdown(w, sets)
+	case outputTemplateFormat:
+		return writeResultSetsTemplate(w, sets)
+	default:
+		return fmt.Errorf("不支持的输出格式: %s", outputFormat)
+	}
+}
+
+// writeResultSetsMarkdown 以 Markdown 写出 fetch 结果
+func writeResultSetsMarkdown(w io.Writer, sets []search.ResultSet) error {
+	var b strings.Builder
+	for i, set := range sets {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n\n", escapeMarkdown(set.Source))
+		if set.Provenance.Synthetic {
+			b.WriteString("> ⚠️ **演示数据：以下内容为模拟生成，并非真实资讯**\n\n")
+		}
+		fmt.Fprintf(&b, "> 数据来源: %s · %s · 获取于 %s\n\n", set.Provenance.Kind, set.Provenance.Backend, formatTimeField(set.Provenance.FetchedAt))
+		for _, result := range set.Results {
+			fmt.Fprintf(&b, "%d. [%s](%s)", result.Index, escapeMarkdown(result.Title), result.URL)
+			if !result.PublishedDate.IsZero() {
+				fmt.Fprintf(&b, " — %s", result.PublishedDate.Local().Format("2006-01-02"))
+			}
+			b.WriteString("\n")
+			if result.Snippet != "" {
+				fmt.Fprintf(&b, "   %s\n", escapeMarkdown(result.Snippet))
+			}
+		}
+		if set.NewOnly {
+			fmt.Fprintf(&b, "\n_%d 条新内容，%d 条此前已见过_\n", len(set.Results), set.KnownCount)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeSubscriptions 按输出格式写出订阅列表
+func writeSubscriptions(w io.Writer, subs []subscription.Subscription) error {
+	if subs == nil {
+		subs = []subscription.Subscription{}
+	}
+
+	switch outputFormat {
+	case outputJSON:
+		return writeJSON(w, subs)
+	case outputNDJSON:
+		return writeNDJSON(w, subs)
+	case outputYAML:
+		return writeYAML(w, subs)
+	case outputCSV:
+		header := []string{"name", "alias", "url", "feed_url", "backend", "category", "tags", "created_at"}
+		var rows [][]string
+		for _, sub := range subs {
+			rows = append(rows, []string{sub.Name, sub.Alias, sub.URL, sub.FeedURL, sub.Backend, sub.Category, strings.Join(sub.Tags, ","), formatTimeField(sub.CreatedAt)})
+		}
+		return writeCSV(w, header, rows)
+	case outputMarkdown:
+		var b strings.Builder
+		b.WriteString("| 名称 | 别名 | URL | 订阅源 | 搜索后端 | 分类 | 标签 | 创建时间 |\n")
+		b.WriteString("| --- | --- | --- | --- | --- | --- | --- | --- |\n")
+		for _, sub := range subs {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+				escapeMarkdownCell(sub.Name), escapeMarkdownCell(sub.Alias), sub.URL, sub.FeedURL, sub.Backend,
+				escapeMarkdownCell(sub.Category), escapeMarkdownCell(strings.Join(sub.Tags, ", ")),
+				sub.CreatedAt.Local().Format("2006-01-02 15:04"))
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	default:
+		return fmt.Errorf("不支持的输出格式: %s", outputFormat)
+	}
+}
+
+// sourceView 官方信息源的机器可读输出字段（README“输出格式”一节列出的字段），
+// 不包含 selectors、options 等抓取器配置
+type sourceView struct {
+	Alias       string `json:"alias"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	FetcherType string `json:"fetcher_type"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Origin      string `json:"origin"`
+}
+
+// writeSources 按输出格式写出官方信息源列表
+func writeSources(w io.Writer, sources []*official.Source) error {
+	views := make([]sourceView, 0, len(sources))
+	for _, source := range sources {
+		views = append(views, sourceView{
+			Alias:       source.Alias,
+			Name:        source.Name,
+			URL:         source.URL,
+			FetcherType: source.FetcherType,
+			Description: source.Description,
+			Enabled:     source.Enabled,
+			Origin:      source.Origin,
+		})
+	}
+
+	switch outputFormat {
+	case outputJSON:
+		return writeJSON(w, views)
+	case outputNDJSON:
+		return writeNDJSON(w, views)
+	case outputYAML:
+		return writeYAML(w, views)
+	case outputCSV:
+		header := []string{"alias", "name", "url", "fetcher_type", "description", "enabled", "origin"}
+		var rows [][]string
+		for _, source := range sources {
+			rows = append(rows, []string{source.Alias, source.Name, source.URL, source.FetcherType, source.Description, strconv.FormatBool(source.Enabled), source.Origin})
+		}
+		return writeCSV(w, header, rows)
+	case outputMarkdown:
+		var b strings.Builder
+		b.WriteString("| 别名 | 名称 | URL | 抓取器 | 说明 | 状态 | 来源 |\n")
+		b.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+		for _, source := range sources {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s |\n",
+				escapeMarkdownCell(source.Alias), escapeMarkdownCell(source.Name), source.URL, source.FetcherType, escapeMarkdownCell(source.Description),
+				sourceStatus(source), sourceOrigin(source))
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	default:
+		return fmt.Errorf("不支持的输出格式: %s", outputFormat)
+	}
+}
+
+// writeFetcherTypes 按输出格式写出已注册的抓取器类型
+func writeFetcherTypes(w io.Writer, types []official.FetcherType) error {
+	switch outputFormat {
+	case outputJSON:
+		return writeJSON(w, types)
+	case outputNDJSON:
+		return writeNDJSON(w, types)
+	case outputYAML:
+		return writeYAML(w, types)
+	case outputCSV:
+		header := []string{"name", "description", "selectors", "options"}
+		var rows [][]string
+		for _, ft := range types {
+			rows = append(rows, []string{ft.Name, ft.Description, strconv.FormatBool(ft.Schema.Selectors), formatOptionSpecs(ft.Schema.Options)})
+		}
+		return writeCSV(w, header, rows)
+	case outputMarkdown:
+		var b strings.Builder
+		b.WriteString("| 类型 | 说明 | 需要 selectors | options 参数 |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, ft := range types {
+			selectors := "否"
+			if ft.Schema.Selectors {
+				selectors = "是"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+				ft.Name, escapeMarkdownCell(ft.Description), selectors, escapeMarkdownCell(formatOptionSpecs(ft.Schema.Options)))
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	default:
+		return fmt.Errorf("不支持的输出格式: %s", outputFormat)
+	}
+}
+
+// formatOptionSpecs 将 options 参数列表格式化为逗号分隔的参数名，必填参数带 * 号
+func formatOptionSpecs(options []official.OptionSpec) string {
+	names := make([]string, 0, len(options))
+	for _, opt := range options {
+		name := opt.Name
+		if opt.Required {
+			name += "*"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ",")
+}
+
+// writeJSON 写出缩进的 JSON
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeNDJSON 每个元素写出一行 JSON
+func writeNDJSON[T any](w io.Writer, items []T) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSV 写出带表头的 CSV
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatTimeField 将时间格式化为 RFC3339，零值输出空字符串
+func formatTimeField(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// escapeMarkdown 转义 Markdown 链接文本中的特殊字符
+func escapeMarkdown(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, "*", `\*`, "_", `\_`, "`", "\\`")
+	return replacer.Replace(s)
+}
+
+// escapeMarkdownCell 转义 Markdown 表格单元格中的竖线
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(escapeMarkdown(s), "|", `\|`)
+}
+
+// writeYAML 写出 YAML；先序列化为 JSON 以保证字段名和顺序与 JSON 输出一致，
+// 再转换为 YAML 节点交给 yaml.v3 编码，由其处理引号、多行字符串等转义
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	node, err := jsonToYAMLNode(decoder)
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// jsonToYAMLNode 将 JSON 解码为 YAML 节点，保留对象字段顺序
+func jsonToYAMLNode(decoder *json.Decoder) (*yaml.Node, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := jsonToYAMLNode(decoder)
+				if err != nil {
+					return nil, err
+				}
+				key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keyToken.(string)}
+				node.Content = append(node.Content, key, value)
+			}
+			if len(node.Content) == 0 {
+				node.Style = yaml.FlowStyle
+			}
+			_, err := decoder.Token()
+			return node, err
+		case '[':
+			node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			for decoder.More() {
+				value, err := jsonToYAMLNode(decoder)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, value)
+			}
+			if len(node.Content) == 0 {
+				node.Style = yaml.FlowStyle
+			}
+			_, err := decoder.Token()
+			return node, err
+		}
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(t)}, nil
+	case json.Number:
+		tag := "!!int"
+		if _, err := t.Int64(); err != nil {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: t.String()}, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t}, nil
+	}
+	return nil, fmt.Errorf("无法转换为 YAML: %v", token)
+}
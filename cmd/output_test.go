@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"news4coder/internal/official"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestWriteYAMLRoundTrip(t *testing.T) {
+	tests := map[string]any{
+		"colon":          map[string]any{"title": "Go: 新特性"},
+		"colon space":    map[string]any{"title": "key: value"},
+		"hash":           map[string]any{"title": "issue #123", "tag": "#go"},
+		"leading dash":   map[string]any{"title": "- 列表项", "dash": "-"},
+		"multi-line":     map[string]any{"snippet": "第一行\n第二行\n\n  缩进行\n"},
+		"yes no":         map[string]any{"a": "yes", "b": "no", "c": "on", "d": "off", "e": "y", "f": "N"},
+		"null-like":      map[string]any{"a": "null", "b": "~", "c": "Null", "d": ""},
+		"bool-like":      map[string]any{"a": "true", "b": "False"},
+		"number-like":    map[string]any{"a": "123", "b": "1.5", "c": "0x1F", "d": "1e3", "e": ".inf", "f": "0o17"},
+		"date-like":      map[string]any{"a": "2024-08-13", "b": "2024-08-13T10:00:00Z"},
+		"quotes":         map[string]any{"a": `"quoted"`, "b": "it's", "c": `back\slash`},
+		"indicators":     map[string]any{"a": "*anchor", "b": "&ref", "c": "!tag", "d": "|", "e": ">", "f": "%x", "g": "@at", "h": "`tick"},
+		"brackets":       map[string]any{"a": "[1, 2]", "b": "{a: 1}"},
+		"whitespace":     map[string]any{"a": " leading", "b": "trailing ", "c": "\ttab"},
+		"special keys":   map[string]any{"key: colon": 1, "#hash": 2, "- dash": 3, "yes": 4, "123": 5, "": 6},
+		"empty map":      map[string]any{"a": map[string]any{}},
+		"empty slice":    map[string]any{"a": []any{}},
+		"top empty":      []any{},
+		"nested":         []any{map[string]any{"a": []any{map[string]any{}, []any{}, "x"}, "b": map[string]any{"c": nil}}},
+		"numbers":        map[string]any{"int": 42, "neg": -1, "float": 3.25, "big": 1e21},
+		"bools and null": map[string]any{"t": true, "f": false, "n": nil},
+		"unicode":        map[string]any{"title": "中文标题 🎯", "emoji": "✓"},
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeYAML(&buf, input); err != nil {
+				t.Fatalf("writeYAML: %v", err)
+			}
+
+			var got any
+			if err := yaml.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("output is not valid YAML: %v\n%s", err, buf.String())
+			}
+
+			// 与 JSON 序列化后的值比较，数字统一为 float64
+			data, _ := json.Marshal(input)
+			var want any
+			json.Unmarshal(data, &want)
+			if !reflect.DeepEqual(normalizeYAMLValue(got), want) {
+				t.Errorf("round trip mismatch\ngot:  %#v\nwant: %#v\nyaml:\n%s", normalizeYAMLValue(got), want, buf.String())
+			}
+		})
+	}
+}
+
+// normalizeYAMLValue 将 yaml.v3 解码出的数字转换为 float64，便于与 JSON 解码结果比较
+func normalizeYAMLValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			v[key] = normalizeYAMLValue(value)
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = normalizeYAMLValue(value)
+		}
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		return v
+	}
+}
+
+func TestWriteYAMLFieldOrder(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeYAML(&buf, []sourceView{{Alias: "infoq", Name: "InfoQ", Enabled: true, Origin: "builtin"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `- alias: infoq
+  name: InfoQ
+  url: ""
+  fetcher_type: ""
+  description: ""
+  enabled: true
+  origin: builtin
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteSourcesFields(t *testing.T) {
+	defer func(format string) { outputFormat = format }(outputFormat)
+	outputFormat = outputJSON
+
+	sources := []*official.Source{{
+		Alias:       "gonews",
+		Name:        "Go 语言中文网",
+		URL:         "https://studygolang.com/articles",
+		FetcherType: "selector",
+		Enabled:     true,
+		Origin:      official.OriginUser,
+		Selectors:   &official.SelectorConfig{Item: ".article"},
+		Options:     map[string]string{"key": "value"},
+	}}
+
+	var buf bytes.Buffer
+	if err := writeSources(&buf, sources); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for key := range got[0] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	want := "alias,description,enabled,fetcher_type,name,origin,url"
+	if strings.Join(keys, ",") != want {
+		t.Errorf("fields = %s, want %s", strings.Join(keys, ","), want)
+	}
+
+	buf.Reset()
+	if err := writeSources(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("empty sources = %q, want []", buf.String())
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"news4coder/internal/storage"
 	"news4coder/internal/subscription"
 
 	"github.com/fatih/color"
@@ -33,42 +32,30 @@ var removeCmd = &cobra.Command{
 		}
 
 		// 创建存储实例
-		store, err := storage.New()
+		store, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("初始化存储失败: %w", err)
 		}
 
-		// 加载配置
-		config, err := store.Load()
-		if err != nil {
-			return fmt.Errorf("加载配置失败: %w", err)
-		}
-
-		// 创建订阅管理器
-		manager := subscription.NewManager(config)
-
 		// 删除订阅
 		var deletedName string
-		if removeName != "" {
-			deletedName = removeName
-			if err := manager.Remove(removeName); err != nil {
-				return err
+		err = store.Update(func(config *subscription.Config) error {
+			manager := newManager(config)
+			if removeName != "" {
+				deletedName = removeName
+				return manager.Remove(removeName)
 			}
-		} else {
+
 			// 通过序号删除
 			subs := manager.List()
 			if removeIndex < 1 || removeIndex > len(subs) {
 				return fmt.Errorf("序号无效: %d（有效范围：1-%d）", removeIndex, len(subs))
 			}
 			deletedName = subs[removeIndex-1].Name
-			if err := manager.RemoveByIndex(removeIndex); err != nil {
-				return err
-			}
-		}
-
-		// 保存配置
-		if err := store.Save(config); err != nil {
-			return fmt.Errorf("保存配置失败: %w", err)
+			return manager.RemoveByIndex(removeIndex)
+		})
+		if err != nil {
+			return err
 		}
 
 		// 输出成功消息
@@ -83,4 +70,5 @@ func init() {
 	rootCmd.AddCommand(removeCmd)
 	removeCmd.Flags().StringVarP(&removeName, "name", "n", "", "订阅名称")
 	removeCmd.Flags().IntVarP(&removeIndex, "index", "i", 0, "订阅序号")
+	registerFlagCompletion(removeCmd, "name", completeSubscriptions)
 }
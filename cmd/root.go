@@ -1,84 +1,117 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"news4coder/internal/official"
+	"news4coder/internal/history"
+	"news4coder/internal/search"
+	"news4coder/internal/storage"
+	"news4coder/internal/subscription"
 	"os"
+	"os/signal"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+// configPath 全局 --config 参数
+var configPath string
+
+// storageOverride 通过 SetStorage 指定的存储实现
+var storageOverride storage.Storage
+
+// historyOverride 通过 SetHistoryStore 指定的历史记录存储
+var historyOverride *history.Store
+
+// SetStorage 替换命令使用的订阅存储（例如内存存储），传入 nil 时恢复默认的文件存储；
+// 需在 Execute 之前调用。抓取历史单独保存，需要时使用 SetHistoryStore 一并替换
+func SetStorage(s storage.Storage) {
+	storageOverride = s
+}
+
+// SetHistoryStore 替换命令使用的历史记录存储（例如临时目录下的文件），
+// 传入 nil 时恢复默认位置（数据目录下的 history.json）；需在 Execute 之前调用
+func SetHistoryStore(s *history.Store) {
+	historyOverride = s
+}
+
+// openStorage 返回命令使用的订阅存储
+func openStorage() (storage.Storage, error) {
+	if storageOverride != nil {
+		return storageOverride, nil
+	}
+	return storage.New()
+}
+
+// openHistory 返回命令使用的历史记录存储
+func openHistory() (*history.Store, error) {
+	if historyOverride != nil {
+		return historyOverride, nil
+	}
+	return history.New()
+}
+
+// newManager 创建订阅管理器，使用 search 包校验订阅的搜索后端
+func newManager(config *subscription.Config) *subscription.Manager {
+	manager := subscription.NewManager(config)
+	manager.SetBackendValidator(validateBackend)
+	return manager
+}
+
+// validateBackend 校验搜索后端名称
+func validateBackend(name string) error {
+	if !search.IsValidBackend(name) {
+		return fmt.Errorf("不支持的搜索后端: %s（可选: %s）", name, strings.Join(search.BackendNames(), ", "))
+	}
+	return nil
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "news4coder",
 	Short: "程序员新闻订阅 CLI 工具",
 	Long: `news4coder 是一个为程序员设计的新闻订阅命令行工具。
-它可以帮助你订阅技术网站，并通过 Bing 站内搜索快速获取最新内容。
+它可以帮助你订阅技术网站，通过 RSS/Atom 订阅源或站内搜索（DuckDuckGo、Bing、SearXNG）
+快速获取最新内容。
 
-官方新闻源快捷访问:
-  infoq       InfoQ 中文站热点清单
+每个官方新闻源都有同名的快捷命令（例如 news4coder infoq），
+使用 "news4coder sources" 查看所有官方新闻源
 
-使用 "news4coder sources" 查看所有官方新闻源`,
-	// 关闭默认的未知命令错误，允许自定义处理
+配置文件位置（优先级从高到低）：
+  --config 参数
+  $NEWS4CODER_HOME/subscriptions.json
+  ~/.news4coder/subscriptions.json（旧版本的安装，文件已存在时）
+  $XDG_CONFIG_HOME/news4coder/subscriptions.json（默认 ~/.config/news4coder）
+抓取历史保存在 --config 所在目录、$NEWS4CODER_HOME、~/.news4coder（旧版本的安装）
+或 $XDG_DATA_HOME/news4coder（默认 ~/.local/share/news4coder）下`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		storage.SetConfigPath(configPath)
+	},
+	// 错误由 Execute 统一输出
 	SilenceErrors: true,
 	SilenceUsage:  true,
 }
 
-// Execute 执行根命令
-func Execute() {
-	err := rootCmd.Execute()
-	if err != nil {
-		// 检查是否为未知命令错误
-		if strings.Contains(err.Error(), "unknown command") {
-			// 提取命令名
-			if len(os.Args) > 1 {
-				alias := os.Args[1]
-				// 尝试作为官方源别名处理
-				if handleErr := handleOfficialSource(alias); handleErr == nil {
-					return
-				} else {
-					// 如果官方源处理也失败，输出具体错误
-					fmt.Fprintln(os.Stderr, handleErr)
-					os.Exit(1)
-					return
-				}
-			}
-		}
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "订阅配置文件路径（默认按 NEWS4CODER_HOME、XDG_CONFIG_HOME 查找）")
 }
 
-// handleOfficialSource 处理官方源别名命令
-func handleOfficialSource(alias string) error {
-	// 获取官方源注册表
-	registry := official.GetRegistry()
-	source, exists := registry.Get(alias)
-	if !exists {
-		return fmt.Errorf("未知命令: %s\n\n运行 'news4coder --help' 查看可用命令", alias)
-	}
-
-	// 显示提示信息
-	cyan := color.New(color.FgCyan).SprintFunc()
-	fmt.Printf("%s 正在获取 %s 的最新内容...\n", cyan("⟳"), source.Name)
-	fmt.Println()
+// Execute 执行根命令
+func Execute() {
+	// 收到 Ctrl-C 时取消进行中的请求；再次按下 Ctrl-C 将直接退出
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
 
-	// 创建抓取器工厂
-	factory := official.NewFetcherFactory()
-	fetcher, err := factory.Create(source)
-	if err != nil {
-		return fmt.Errorf("创建抓取器失败: %w", err)
-	}
+	// 在执行时生成官方源命令，确保其他包注册的抓取器类型已经生效；
+	// 生成前先应用 --config，使官方源从对应配置目录下的 sources.json 加载
+	storage.SetConfigPath(configFlagFromArgs(os.Args[1:]))
+	addOfficialSourceCommands(isCompletionRequest(os.Args[1:]))
 
-	// 执行抓取
-	results, err := fetcher.Fetch()
-	if err != nil {
-		return fmt.Errorf("获取内容失败: %w", err)
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-
-	// 显示结果
-	displayResults(results, source.Name)
-
-	return nil
 }
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// executeCommand 以 args 执行根命令并返回标准输出；执行后重置所有命令的参数，
+// 避免参数值（尤其是可重复的切片参数）带入后续测试
+func executeCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+
+	rootCmd.SetArgs(args)
+	execErr := rootCmd.ExecuteContext(context.Background())
+
+	w.Close()
+	os.Stdout = stdout
+	out := <-output
+
+	rootCmd.SetArgs(nil)
+	resetFlags(rootCmd)
+	return out, execErr
+}
+
+// resetFlags 将命令及其子命令的参数恢复为默认值
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, c := range cmd.Commands() {
+		resetFlags(c)
+	}
+}
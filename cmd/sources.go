@@ -3,20 +3,44 @@ package cmd
 import (
 	"fmt"
 	"news4coder/internal/official"
+	"os"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var sourcesTypes bool
+
 var sourcesCmd = &cobra.Command{
 	Use:   "sources",
 	Short: "列出所有官方新闻源",
-	Long:  `显示所有可用的官方新闻源及其别名。`,
-	Example: `  news4coder sources`,
+	Long: `显示所有官方新闻源及其别名、状态和定义来源。
+
+内置官方源随程序发布；配置目录下的 sources.json 可以新增官方源，
+或按别名覆盖内置源的字段（例如 url、enabled），详见 README 的“自定义官方源”一节。
+来源一栏：内置（builtin）、用户（user，由 sources.json 新增）、
+已覆盖（override，内置源被 sources.json 修改）。
+
+使用 --types 列出可用的抓取器类型（fetcher_type）及其配置要求。
+` + outputSchemaHelp,
+	Example: `  news4coder sources
+  news4coder sources -o yaml
+  news4coder sources --types`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// 获取官方源注册表
-		registry := official.GetRegistry()
-		sources := registry.List()
+		if err := validateOutputFormat(); err != nil {
+			return err
+		}
+
+		if sourcesTypes {
+			return showFetcherTypes()
+		}
+
+		// 获取官方源注册表（包括已停用的源）；sources.json 的加载警告已在生成官方源命令时输出
+		sources := official.GetRegistry().All()
+
+		if isMachineOutput() {
+			return writeSources(os.Stdout, sources)
+		}
 
 		if len(sources) == 0 {
 			fmt.Println("暂无可用的官方新闻源")
@@ -35,10 +59,10 @@ var sourcesCmd = &cobra.Command{
 
 		// 显示源列表
 		blue := color.New(color.FgBlue).SprintFunc()
+		gray := color.New(color.FgHiBlack).SprintFunc()
 		for _, source := range sources {
-			fmt.Printf("%-8s %s\n", blue(source.Alias), source.Name)
+			fmt.Printf("%-8s %s %s\n", blue(source.Alias), source.Name, gray("["+sourceStatus(source)+" · "+sourceOrigin(source)+"]"))
 			if source.Description != "" {
-				gray := color.New(color.FgHiBlack).SprintFunc()
 				fmt.Printf("         %s\n", gray(source.Description))
 			}
 		}
@@ -48,15 +72,71 @@ var sourcesCmd = &cobra.Command{
 		fmt.Println()
 
 		// 使用提示
-		gray := color.New(color.FgHiBlack).SprintFunc()
 		fmt.Println(gray("💡 使用方法: news4coder <别名>"))
 		fmt.Println(gray("💡 示例: news4coder infoq"))
+		if path, err := official.UserSourcesPath(); err == nil {
+			fmt.Println(gray("💡 自定义官方源: " + path))
+		}
 		fmt.Println()
 
 		return nil
 	},
 }
 
+// showFetcherTypes 显示已注册的抓取器类型
+func showFetcherTypes() error {
+	types := official.FetcherTypes()
+	if isMachineOutput() {
+		return writeFetcherTypes(os.Stdout, types)
+	}
+
+	bold := color.New(color.Bold).SprintFunc()
+	blue := color.New(color.FgBlue).SprintFunc()
+	gray := color.New(color.FgHiBlack).SprintFunc()
+
+	fmt.Println(bold("━━━ 抓取器类型 ━━━"))
+	fmt.Println()
+	for _, ft := range types {
+		fmt.Printf("%-10s %s\n", blue(ft.Name), ft.Description)
+		if ft.Schema.Selectors {
+			fmt.Printf("           %s\n", gray("需要 selectors 选择器配置"))
+		}
+		for _, opt := range ft.Schema.Options {
+			required := "可选"
+			if opt.Required {
+				required = "必填"
+			}
+			fmt.Printf("           %s\n", gray(fmt.Sprintf("options.%s（%s）：%s", opt.Name, required, opt.Description)))
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+// sourceStatus 返回官方源的启用状态
+func sourceStatus(source *official.Source) string {
+	if source.Enabled {
+		return "启用"
+	}
+	return "停用"
+}
+
+// sourceOrigin 返回官方源定义来源的中文说明
+func sourceOrigin(source *official.Source) string {
+	switch source.Origin {
+	case official.OriginBuiltin:
+		return "内置"
+	case official.OriginUser:
+		return "用户"
+	case official.OriginOverride:
+		return "内置（已覆盖）"
+	default:
+		return source.Origin
+	}
+}
+
 func init() {
+	addOutputFlag(sourcesCmd)
+	sourcesCmd.Flags().BoolVar(&sourcesTypes, "types", false, "列出可用的抓取器类型")
 	rootCmd.AddCommand(sourcesCmd)
 }
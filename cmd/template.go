@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"news4coder/internal/search"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// outputTemplateFormat 使用自定义模板输出，由 --format 或 --template 启用
+const outputTemplateFormat = "template"
+
+var (
+	// resultTemplate 解析后的自定义模板
+	resultTemplate *template.Template
+	// resultTemplatePerItem 为 true 时模板对每条结果执行一次（--format），
+	// 否则对每个信息源的结果集执行一次（--template）
+	resultTemplatePerItem bool
+)
+
+// templateHelp 自定义模板的数据模型说明，附加在命令帮助中
+const templateHelp = `
+自定义模板（Go text/template）：
+  --format    对每条结果执行一次，可用字段：
+              .Index .Title .URL .Snippet .PublishedDate
+              .Source .SourceURL .Mode .Provenance
+              输出末尾没有换行时自动补上换行
+  --template  对每个信息源执行一次，可用字段：
+              .Source .SourceURL .Mode .Provenance .Results .NewOnly .KnownCount
+              其中 .Results 为结果列表，字段同上
+  .Provenance 包含 .Kind .FetchedAt .Backend .Synthetic
+辅助函数：
+  truncate N         按字符截断，超出时添加 "..."
+  date "2006-01-02"  格式化时间（Go 时间布局），零值输出空字符串
+  host               提取链接的主机名`
+
+// templateFuncs 模板辅助函数
+var templateFuncs = template.FuncMap{
+	"truncate": templateTruncate,
+	"date":     templateDate,
+	"host":     templateHost,
+}
+
+// loadResultTemplate 解析 --format 或 --template 指定的模板，并切换到模板输出
+func loadResultTemplate(format, file string) error {
+	if format == "" && file == "" {
+		return nil
+	}
+	if format != "" && file != "" {
+		return fmt.Errorf("--format 和 --template 不能同时使用")
+	}
+	if outputFormat != outputText {
+		return fmt.Errorf("--format/--template 不能与 --output %s 同时使用", outputFormat)
+	}
+
+	name, text := "format", format
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("读取模板文件失败: %w", err)
+		}
+		name, text = filepath.Base(file), string(data)
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("解析模板失败: %w", err)
+	}
+
+	resultTemplate = tmpl
+	resultTemplatePerItem = format != ""
+	outputFormat = outputTemplateFormat
+	return nil
+}
+
+// writeResultSetsTemplate 使用自定义模板写出 fetch 结果
+func writeResultSetsTemplate(w io.Writer, sets []search.ResultSet) error {
+	if !resultTemplatePerItem {
+		for _, set := range sets {
+			if err := resultTemplate.Execute(w, set); err != nil {
+				return fmt.Errorf("执行模板失败: %w", err)
+			}
+		}
+		return nil
+	}
+
+	for _, record := range flattenResultSets(sets) {
+		var b strings.Builder
+		if err := resultTemplate.Execute(&b, record); err != nil {
+			return fmt.Errorf("执行模板失败: %w", err)
+		}
+		line := b.String()
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateTruncate 按字符截断字符串，参数顺序便于在管道中使用：{{.Title | truncate 40}}
+func templateTruncate(n int, s string) string {
+	runes := []rune(s)
+	if n <= 0 || len(runes) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(runes[:n])
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+// templateDate 按布局格式化时间，零值返回空字符串：{{.PublishedDate | date "2006-01-02"}}
+func templateDate(layout string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(layout)
+}
+
+// templateHost 提取链接的主机名：{{.URL | host}}
+func templateHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"news4coder/internal/search"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTemplateTruncate(t *testing.T) {
+	tests := []struct {
+		n    int
+		in   string
+		want string
+	}{
+		{10, "short", "short"},
+		{5, "exact", "exact"},
+		{6, "truncated text", "tru..."},
+		{5, "Go 语言新特性详解", "Go..."},
+		{6, "中文标题很长很长", "中文标..."},
+		{4, "中文标题", "中文标题"},
+		{3, "中文标题", "中文标"},
+		{2, "abcdef", "ab"},
+		{1, "中文", "中"},
+		{0, "unchanged", "unchanged"},
+		{-1, "unchanged", "unchanged"},
+		{5, "", ""},
+	}
+	for _, tt := range tests {
+		got := templateTruncate(tt.n, tt.in)
+		if got != tt.want {
+			t.Errorf("truncate %d %q = %q, want %q", tt.n, tt.in, got, tt.want)
+		}
+		if tt.n > 0 && len([]rune(got)) > tt.n {
+			t.Errorf("truncate %d %q = %q exceeds %d runes", tt.n, tt.in, got, tt.n)
+		}
+	}
+}
+
+func TestTemplateDate(t *testing.T) {
+	if got := templateDate("2006-01-02", time.Time{}); got != "" {
+		t.Errorf("zero time = %q, want empty", got)
+	}
+	when := time.Date(2024, 8, 13, 9, 30, 0, 0, time.Local)
+	if got := templateDate("2006-01-02 15:04", when); got != "2024-08-13 09:30" {
+		t.Errorf("date = %q", got)
+	}
+	if got := templateDate("2006-01-02", when.UTC()); got != "2024-08-13" {
+		t.Errorf("date is not converted to local time: %q", got)
+	}
+}
+
+func TestTemplateHost(t *testing.T) {
+	tests := map[string]string{
+		"https://go.dev/blog/go1.23":   "go.dev",
+		"https://www.infoq.cn/article": "www.infoq.cn",
+		"http://localhost:8080/feed":   "localhost",
+		"/relative/path":               "",
+		"://bad":                       "",
+		"":                             "",
+	}
+	for in, want := range tests {
+		if got := templateHost(in); got != want {
+			t.Errorf("host %q = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// useTemplate 解析模板并在测试结束后恢复输出格式
+func useTemplate(t *testing.T, format, file string) {
+	t.Helper()
+	previous := outputFormat
+	outputFormat = outputText
+	t.Cleanup(func() {
+		outputFormat = previous
+		resultTemplate = nil
+		resultTemplatePerItem = false
+	})
+	if err := loadResultTemplate(format, file); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var templateSets = []search.ResultSet{
+	{Source: "Go 博客", Results: []search.SearchResult{
+		{Index: 1, Title: "Go 1.23", URL: "https://go.dev/blog/go1.23"},
+		{Index: 2, Title: "Range functions", URL: "https://go.dev/blog/range-functions"},
+	}},
+	{Source: "InfoQ", Results: []search.SearchResult{
+		{Index: 1, Title: "架构", URL: "https://www.infoq.cn/article/x"},
+	}},
+}
+
+func TestFormatTemplateAddsNewline(t *testing.T) {
+	for _, format := range []string{`{{.Source}}|{{.Index}}|{{.URL | host}}`, "{{.Source}}|{{.Index}}|{{.URL | host}}\n"} {
+		useTemplate(t, format, "")
+
+		var b bytes.Buffer
+		if err := writeResultSetsTemplate(&b, templateSets); err != nil {
+			t.Fatal(err)
+		}
+		want := "Go 博客|1|go.dev\nGo 博客|2|go.dev\nInfoQ|1|www.infoq.cn\n"
+		if b.String() != want {
+			t.Errorf("format %q output = %q, want %q", format, b.String(), want)
+		}
+	}
+}
+
+func TestTemplateFileRunsPerSet(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "digest.tmpl")
+	text := `## {{.Source}}{{range .Results}} [{{.Index}}]{{.Title | truncate 7}}{{end}};`
+	if err := os.WriteFile(file, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+	useTemplate(t, "", file)
+
+	var b bytes.Buffer
+	if err := writeResultSetsTemplate(&b, templateSets); err != nil {
+		t.Fatal(err)
+	}
+	// --template 的输出原样写出，不自动补换行
+	want := "## Go 博客 [1]Go 1.23 [2]Rang...;## InfoQ [1]架构;"
+	if b.String() != want {
+		t.Errorf("output = %q, want %q", b.String(), want)
+	}
+}
+
+func TestLoadResultTemplateErrors(t *testing.T) {
+	previous := outputFormat
+	t.Cleanup(func() { outputFormat = previous })
+
+	tests := []struct {
+		name   string
+		output string
+		format string
+		file   string
+		want   string
+	}{
+		{"both", outputText, "{{.Title}}", "x.tmpl", "不能同时使用"},
+		{"with output", outputJSON, "{{.Title}}", "", "--output json"},
+		{"parse error", outputText, "{{.Title", "", "解析模板失败"},
+		{"unknown func", outputText, "{{.Title | shout}}", "", "解析模板失败"},
+		{"missing file", outputText, "", filepath.Join(t.TempDir(), "missing.tmpl"), "读取模板文件失败"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFormat = tt.output
+			err := loadResultTemplate(tt.format, tt.file)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package history
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// trackingParams 不影响内容的跟踪参数，规范化时移除
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"ref":     true,
+	"ref_src": true,
+	"spm":     true,
+}
+
+// CanonicalURL 规范化文章链接，用于判断是否为同一篇内容：
+// 统一协议和主机名大小写、去除 www. 前缀、片段、末尾斜杠以及 utm_* 等跟踪参数
+func CanonicalURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+
+	parsed.Scheme = "https"
+	parsed.Host = strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+	parsed.RawPath = ""
+
+	query := parsed.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || trackingParams[strings.ToLower(key)] {
+			query.Del(key)
+		}
+	}
+
+	// 参数按名称排序，避免顺序不同导致重复
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+	parsed.RawQuery = strings.Join(parts, "&")
+
+	return parsed.String()
+}
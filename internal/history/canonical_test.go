@@ -0,0 +1,41 @@
+package history
+
+import "testing"
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "https://go.dev/blog/go1.23", "https://go.dev/blog/go1.23"},
+		{"http to https", "http://go.dev/blog/go1.23", "https://go.dev/blog/go1.23"},
+		{"host case", "https://Go.DEV/blog/go1.23", "https://go.dev/blog/go1.23"},
+		{"www prefix", "https://www.infoq.cn/article/abc", "https://infoq.cn/article/abc"},
+		{"trailing slash", "https://go.dev/blog/go1.23/", "https://go.dev/blog/go1.23"},
+		{"fragment", "https://go.dev/blog/go1.23#generics", "https://go.dev/blog/go1.23"},
+		{"utm params", "https://go.dev/blog?utm_source=rss&utm_medium=feed&UTM_Campaign=x", "https://go.dev/blog"},
+		{"tracking params", "https://example.com/a?ref=hn&fbclid=1&gclid=2&spm=3&ref_src=twsrc", "https://example.com/a"},
+		{"keeps content params", "https://news.ycombinator.com/item?id=123&utm_source=x", "https://news.ycombinator.com/item?id=123"},
+		{"param order", "https://example.com/search?q=go&page=2", "https://example.com/search?page=2&q=go"},
+		{"repeated param", "https://example.com/a?tag=b&tag=a", "https://example.com/a?tag=b&tag=a"},
+		{"escaped value", "https://example.com/s?q=go%20lang", "https://example.com/s?q=go+lang"},
+		{"surrounding space", "  https://go.dev/blog  ", "https://go.dev/blog"},
+		{"relative", "/blog/post", "/blog/post"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanonicalURL(tt.in); got != tt.want {
+				t.Errorf("CanonicalURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	// 只有参数顺序、跟踪参数或 www. 不同的链接视为同一篇内容
+	a := CanonicalURL("https://www.example.com/post?b=2&a=1&utm_source=rss#top")
+	b := CanonicalURL("http://example.com/post/?a=1&b=2")
+	if a != b {
+		t.Errorf("equivalent URLs differ: %q vs %q", a, b)
+	}
+}
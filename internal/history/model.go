@@ -0,0 +1,187 @@
+package history
+
+import (
+	"news4coder/internal/search"
+	"sort"
+	"time"
+)
+
+// Item 表示一条抓取过的内容
+type Item struct {
+	Source        string    `json:"source"`                  // 信息源名称
+	URL           string    `json:"url"`                     // 文章链接
+	CanonicalURL  string    `json:"canonical_url"`           // 规范化后的文章链接，用于去重
+	Title         string    `json:"title"`                   // 文章标题
+	Snippet       string    `json:"snippet,omitempty"`       // 内容摘要
+	PublishedDate time.Time `json:"published_date,omitzero"` // 发布时间（如果可提取）
+	FirstSeen     time.Time `json:"first_seen"`              // 首次抓取时间
+	LastSeen      time.Time `json:"last_seen"`               // 最近抓取时间
+}
+
+// History 表示历史记录文件结构
+type History struct {
+	Items []Item `json:"items"` // 历史条目
+
+	index map[itemKey]int // 按信息源和链接索引条目位置
+}
+
+// itemKey 条目唯一键
+type itemKey struct {
+	source string
+	url    string
+}
+
+// buildIndex 重建条目索引
+func (h *History) buildIndex() {
+	h.index = make(map[itemKey]int, len(h.Items))
+	for i, item := range h.Items {
+		h.index[itemKey{item.Source, item.CanonicalURL}] = i
+	}
+}
+
+// Lookup 查找信息源下指定链接的条目
+func (h *History) Lookup(source, rawURL string) (*Item, bool) {
+	if h.index == nil {
+		h.buildIndex()
+	}
+	i, ok := h.index[itemKey{source, CanonicalURL(rawURL)}]
+	if !ok {
+		return nil, false
+	}
+	return &h.Items[i], true
+}
+
+// Record 记录一次抓取结果：新条目写入首次抓取时间，已有条目更新最近抓取时间和内容
+func (h *History) Record(source string, results []search.SearchResult, seenAt time.Time) {
+	if h.index == nil {
+		h.buildIndex()
+	}
+
+	for _, result := range results {
+		key := itemKey{source, CanonicalURL(result.URL)}
+		if key.url == "" {
+			continue
+		}
+
+		if i, ok := h.index[key]; ok {
+			item := &h.Items[i]
+			item.LastSeen = seenAt
+			item.URL = result.URL
+			item.Title = result.Title
+			if result.Snippet != "" {
+				item.Snippet = result.Snippet
+			}
+			if !result.PublishedDate.IsZero() {
+				item.PublishedDate = result.PublishedDate
+			}
+			continue
+		}
+
+		h.Items = append(h.Items, Item{
+			Source:        source,
+			URL:           result.URL,
+			CanonicalURL:  key.url,
+			Title:         result.Title,
+			Snippet:       result.Snippet,
+			PublishedDate: result.PublishedDate,
+			FirstSeen:     seenAt,
+			LastSeen:      seenAt,
+		})
+		h.index[key] = len(h.Items) - 1
+	}
+}
+
+// Unseen 返回信息源下尚未记录过的结果（重新编号）及已记录过的条目数
+func (h *History) Unseen(source string, results []search.SearchResult) ([]search.SearchResult, int) {
+	fresh := []search.SearchResult{}
+	known := 0
+	for _, result := range results {
+		if _, ok := h.Lookup(source, result.URL); ok {
+			known++
+			continue
+		}
+		result.Index = len(fresh) + 1
+		fresh = append(fresh, result)
+	}
+	return fresh, known
+}
+
+// RenameSource 将信息源 from 的条目改记到 to 下（订阅改名时调用），返回改记的条目数；
+// to 下已有相同链接的条目时合并，保留较早的首次抓取时间，内容取最近抓取到的一条
+func (h *History) RenameSource(from, to string) int {
+	if from == to {
+		return 0
+	}
+
+	moved := 0
+	items := make([]Item, 0, len(h.Items))
+	merged := make(map[string]int) // 规范化链接 -> to 条目在 items 中的位置
+	for _, item := range h.Items {
+		if item.Source == from {
+			item.Source = to
+			moved++
+		}
+		if item.Source != to {
+			items = append(items, item)
+			continue
+		}
+
+		j, ok := merged[item.CanonicalURL]
+		if !ok {
+			merged[item.CanonicalURL] = len(items)
+			items = append(items, item)
+			continue
+		}
+		existing := &items[j]
+		firstSeen := existing.FirstSeen
+		if item.FirstSeen.Before(firstSeen) {
+			firstSeen = item.FirstSeen
+		}
+		if item.LastSeen.After(existing.LastSeen) {
+			*existing = item
+		}
+		existing.FirstSeen = firstSeen
+	}
+
+	h.Items = items
+	h.buildIndex()
+	return moved
+}
+
+// Prune 清理最近抓取时间早于 now-maxAge 的条目；仍超过 maxItems 条时，
+// 按最近抓取时间从旧到新继续清理。保留条目的相对顺序不变
+func (h *History) Prune(now time.Time, maxAge time.Duration, maxItems int) {
+	cutoff := now.Add(-maxAge)
+	kept := h.Items[:0]
+	for _, item := range h.Items {
+		if !item.LastSeen.Before(cutoff) {
+			kept = append(kept, item)
+		}
+	}
+
+	if len(kept) > maxItems {
+		// 按最近抓取时间从新到旧排序，保留前 maxItems 条
+		order := make([]int, len(kept))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return kept[order[a]].LastSeen.After(kept[order[b]].LastSeen)
+		})
+		keep := make([]bool, len(kept))
+		for _, i := range order[:maxItems] {
+			keep[i] = true
+		}
+
+		trimmed := kept[:0]
+		for i, item := range kept {
+			if keep[i] {
+				trimmed = append(trimmed, item)
+			}
+		}
+		kept = trimmed
+	}
+
+	h.Items = kept
+	h.buildIndex()
+}
@@ -0,0 +1,101 @@
+package history
+
+import (
+	"news4coder/internal/search"
+	"testing"
+	"time"
+)
+
+func results(urls ...string) []search.SearchResult {
+	var rs []search.SearchResult
+	for i, u := range urls {
+		rs = append(rs, search.SearchResult{Index: i + 1, Title: "t" + u, URL: u})
+	}
+	return rs
+}
+
+func TestUnseen(t *testing.T) {
+	h := &History{}
+	h.Record("Go 博客", results("https://go.dev/blog/a", "https://go.dev/blog/b"), time.Now())
+
+	fetched := results(
+		"https://go.dev/blog/c",
+		"http://www.go.dev/blog/a/?utm_source=rss", // 与已记录的 a 规范化后相同
+		"https://go.dev/blog/d",
+		"https://go.dev/blog/b#comments",
+	)
+	fresh, known := h.Unseen("Go 博客", fetched)
+
+	if known != 2 {
+		t.Errorf("known = %d, want 2", known)
+	}
+	if len(fresh) != 2 || fresh[0].URL != "https://go.dev/blog/c" || fresh[1].URL != "https://go.dev/blog/d" {
+		t.Fatalf("fresh = %+v", fresh)
+	}
+	if fresh[0].Index != 1 || fresh[1].Index != 2 {
+		t.Errorf("fresh not renumbered: %d, %d", fresh[0].Index, fresh[1].Index)
+	}
+
+	// 其他信息源的历史不影响判断
+	if fresh, known := h.Unseen("其他", fetched); len(fresh) != 4 || known != 0 {
+		t.Errorf("other source: %d fresh, %d known", len(fresh), known)
+	}
+
+	// 全部见过时返回空列表而不是 nil，便于输出 []
+	if fresh, known := h.Unseen("Go 博客", results("https://go.dev/blog/a")); fresh == nil || len(fresh) != 0 || known != 1 {
+		t.Errorf("all known: fresh = %#v, known = %d", fresh, known)
+	}
+}
+
+func TestRecordUpdatesExisting(t *testing.T) {
+	first := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+
+	h := &History{}
+	h.Record("Go 博客", results("https://go.dev/blog/a"), first)
+	h.Record("Go 博客", []search.SearchResult{{Title: "新标题", URL: "https://www.go.dev/blog/a/"}}, second)
+
+	if len(h.Items) != 1 {
+		t.Fatalf("got %d items, want 1", len(h.Items))
+	}
+	item := h.Items[0]
+	if !item.FirstSeen.Equal(first) || !item.LastSeen.Equal(second) || item.Title != "新标题" {
+		t.Errorf("item = %+v", item)
+	}
+}
+
+func TestRenameSource(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 8, d, 0, 0, 0, 0, time.UTC) }
+
+	h := &History{}
+	h.Record("旧名称", results("https://example.com/a", "https://example.com/b"), day(1))
+	h.Record("新名称", []search.SearchResult{{Title: "a 的新标题", URL: "https://example.com/a"}}, day(5))
+	h.Record("其他", results("https://example.com/a"), day(2))
+
+	if moved := h.RenameSource("旧名称", "新名称"); moved != 2 {
+		t.Errorf("moved = %d, want 2", moved)
+	}
+
+	if fresh, known := h.Unseen("新名称", results("https://example.com/a", "https://example.com/b")); len(fresh) != 0 || known != 2 {
+		t.Errorf("after rename: %d fresh, %d known", len(fresh), known)
+	}
+	if fresh, _ := h.Unseen("旧名称", results("https://example.com/a")); len(fresh) != 1 {
+		t.Error("old name still has history")
+	}
+	if _, ok := h.Lookup("其他", "https://example.com/a"); !ok {
+		t.Error("other source lost its history")
+	}
+
+	// 重复链接合并为一条：保留最早的首次抓取时间，内容取最近抓取到的一条
+	if len(h.Items) != 3 {
+		t.Fatalf("got %d items, want 3: %+v", len(h.Items), h.Items)
+	}
+	item, _ := h.Lookup("新名称", "https://example.com/a")
+	if !item.FirstSeen.Equal(day(1)) || !item.LastSeen.Equal(day(5)) || item.Title != "a 的新标题" {
+		t.Errorf("merged item = %+v", item)
+	}
+
+	if moved := h.RenameSource("新名称", "新名称"); moved != 0 {
+		t.Errorf("rename to itself moved %d items", moved)
+	}
+}
@@ -0,0 +1,92 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"news4coder/internal/storage"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const historyFile = "history.json"
+
+// 历史记录保留策略
+const (
+	// MaxAge 最近抓取时间早于该时长的条目会被清理
+	MaxAge = 180 * 24 * time.Hour
+	// MaxItems 最多保留的条目数，超出时清理最近抓取时间最早的条目
+	MaxItems = 5000
+)
+
+// Store 提供历史记录的持久化功能
+type Store struct {
+	path string
+}
+
+// New 创建新的历史记录存储实例（数据目录下的 history.json，见 storage.DataDir）
+func New() (*Store, error) {
+	dir, err := storage.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewFile(filepath.Join(dir, historyFile)), nil
+}
+
+// NewFile 创建使用指定文件的历史记录存储实例
+func NewFile(path string) *Store {
+	return &Store{path: path}
+}
+
+// Load 从历史记录文件加载，文件不存在时返回空记录
+func (s *Store) Load() (*History, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return &History{Items: []Item{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("无法读取历史记录: %w", err)
+	}
+
+	var h History
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("历史记录格式错误: %w", err)
+	}
+	h.buildIndex()
+
+	return &h, nil
+}
+
+// Update 在文件锁保护下完成一次“加载-修改-保存”：fn 修改历史记录，返回错误时不保存；
+// 保存前按 MaxAge、MaxItems 清理旧条目。多个进程并发抓取时依次执行，不会丢失条目
+func (s *Store) Update(fn func(h *History) error) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("无法创建数据目录: %w", err)
+	}
+
+	return storage.WithLock(s.path, func() error {
+		h, err := s.Load()
+		if err != nil {
+			return err
+		}
+		if err := fn(h); err != nil {
+			return err
+		}
+		h.Prune(time.Now(), MaxAge, MaxItems)
+		return s.save(h)
+	})
+}
+
+// save 写入历史记录，调用方需持有文件锁
+func (s *Store) save(h *History) error {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("无法序列化历史记录: %w", err)
+	}
+
+	if err := storage.WriteFileAtomic(s.path, data, 0644); err != nil {
+		return fmt.Errorf("无法写入历史记录: %w", err)
+	}
+
+	return nil
+}
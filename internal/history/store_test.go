@@ -0,0 +1,127 @@
+package history
+
+import (
+	"fmt"
+	"news4coder/internal/search"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStoreUpdateConcurrent(t *testing.T) {
+	store := &Store{path: filepath.Join(t.TempDir(), historyFile)}
+	now := time.Now()
+
+	const workers = 16
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := store.Update(func(h *History) error {
+				h.Record("源", []search.SearchResult{
+					{Title: "t", URL: fmt.Sprintf("https://example.com/%d", i)},
+				}, now)
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	h, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Items) != workers {
+		t.Fatalf("got %d items, want %d", len(h.Items), workers)
+	}
+}
+
+func TestStoreUpdateError(t *testing.T) {
+	store := &Store{path: filepath.Join(t.TempDir(), historyFile)}
+	want := fmt.Errorf("中止")
+	err := store.Update(func(h *History) error {
+		h.Record("源", []search.SearchResult{{Title: "t", URL: "https://example.com/a"}}, time.Now())
+		return want
+	})
+	if err != want {
+		t.Fatalf("err = %v, want %v", err, want)
+	}
+
+	h, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Items) != 0 {
+		t.Errorf("history saved despite error: %+v", h.Items)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC)
+	item := func(url string, age time.Duration) Item {
+		return Item{Source: "源", URL: url, CanonicalURL: url, LastSeen: now.Add(-age)}
+	}
+
+	tests := []struct {
+		name     string
+		items    []Item
+		maxAge   time.Duration
+		maxItems int
+		want     []string
+	}{
+		{
+			name:     "age",
+			items:    []Item{item("a", 200*24*time.Hour), item("b", time.Hour), item("c", 181*24*time.Hour)},
+			maxAge:   180 * 24 * time.Hour,
+			maxItems: 10,
+			want:     []string{"b"},
+		},
+		{
+			name:     "count keeps most recent in original order",
+			items:    []Item{item("a", 3*time.Hour), item("b", time.Hour), item("c", 4*time.Hour), item("d", 2*time.Hour)},
+			maxAge:   24 * time.Hour,
+			maxItems: 2,
+			want:     []string{"b", "d"},
+		},
+		{
+			name:     "ties keep earlier items",
+			items:    []Item{item("a", time.Hour), item("b", time.Hour), item("c", time.Hour)},
+			maxAge:   24 * time.Hour,
+			maxItems: 2,
+			want:     []string{"a", "b"},
+		},
+		{
+			name:     "within limits",
+			items:    []Item{item("a", time.Hour)},
+			maxAge:   24 * time.Hour,
+			maxItems: 2,
+			want:     []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &History{Items: tt.items}
+			h.Prune(now, tt.maxAge, tt.maxItems)
+
+			var got []string
+			for _, item := range h.Items {
+				got = append(got, item.URL)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			// 索引与清理后的条目一致
+			for _, url := range tt.want {
+				if _, ok := h.Lookup("源", url); !ok {
+					t.Errorf("Lookup(%q) failed after prune", url)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package official
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// feedLinkTypes 页面 <link rel="alternate"> 中表示订阅源的 MIME 类型
+var feedLinkTypes = []string{
+	"application/rss+xml",
+	"application/atom+xml",
+	"application/rdf+xml",
+}
+
+// commonFeedPaths 常见的订阅源路径，依次尝试
+var commonFeedPaths = []string{
+	"feed",
+	"rss.xml",
+	"atom.xml",
+	"feed.xml",
+	"index.xml",
+	"rss",
+	"feed.atom",
+}
+
+// discoverTimeout 一次订阅源发现的总时限，超时后放弃剩余的候选路径
+const discoverTimeout = 30 * time.Second
+
+// FeedDiscoverer 订阅源自动发现器
+type FeedDiscoverer struct {
+	client  *http.Client
+	timeout time.Duration
+}
+
+// NewFeedDiscoverer 创建订阅源发现器实例
+func NewFeedDiscoverer() *FeedDiscoverer {
+	return &FeedDiscoverer{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		timeout: discoverTimeout,
+	}
+}
+
+// Discover 查找网页对应的 RSS/Atom 订阅源地址
+//
+// 依次检查：页面本身是否为订阅源、页面中的 <link rel="alternate"> 声明、
+// 常见订阅源路径（先在页面路径下查找，再在站点根路径下查找）。
+// 整个发现过程最多持续 30 秒，超时后返回错误。
+func (d *FeedDiscoverer) Discover(ctx context.Context, pageURL string) (string, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("URL格式无效: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	feedURL, err := d.discover(ctx, pageURL, base)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("查找订阅源超时（%s）", d.timeout)
+	}
+	return feedURL, err
+}
+
+// discover 按顺序检查页面本身、页面声明的订阅源和常见路径
+func (d *FeedDiscoverer) discover(ctx context.Context, pageURL string, base *url.URL) (string, error) {
+	body, contentType, err := d.get(ctx, pageURL)
+	if err != nil {
+		return "", err
+	}
+
+	// 页面本身就是订阅源
+	if isFeedContentType(contentType) || looksLikeFeed(body) {
+		if _, err := ParseFeed(body, pageURL); err == nil {
+			return pageURL, nil
+		}
+	}
+
+	// 页面声明的订阅源
+	if doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body)); err == nil {
+		var found string
+		doc.Find(`link[rel~="alternate"]`).EachWithBreak(func(i int, s *goquery.Selection) bool {
+			linkType, _ := s.Attr("type")
+			href, _ := s.Attr("href")
+			if href == "" || !isFeedLinkType(linkType) {
+				return true
+			}
+			if ref, err := base.Parse(strings.TrimSpace(href)); err == nil {
+				found = ref.String()
+				return false
+			}
+			return true
+		})
+		if found != "" {
+			return found, nil
+		}
+	}
+
+	// 常见路径
+	for _, candidate := range candidateFeedURLs(base) {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if d.isFeed(ctx, candidate) {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("未发现订阅源: %s", pageURL)
+}
+
+// get 下载页面内容
+func (d *FeedDiscoverer) get(ctx context.Context, target string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/rss+xml,application/atom+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("网络请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	// 限制读取大小，避免下载过大的页面
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 5<<20))
+	if err != nil {
+		return nil, "", fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+// isFeed 判断地址是否为可解析的订阅源
+func (d *FeedDiscoverer) isFeed(ctx context.Context, target string) bool {
+	body, _, err := d.get(ctx, target)
+	if err != nil || !looksLikeFeed(body) {
+		return false
+	}
+	results, err := ParseFeed(body, target)
+	return err == nil && len(results) > 0
+}
+
+// candidateFeedURLs 生成常见订阅源路径候选列表
+func candidateFeedURLs(base *url.URL) []string {
+	var prefixes []string
+	if dir := strings.TrimSuffix(base.Path, "/"); dir != "" {
+		prefixes = append(prefixes, dir+"/")
+	}
+	prefixes = append(prefixes, "/")
+
+	var candidates []string
+	for _, prefix := range prefixes {
+		for _, path := range commonFeedPaths {
+			u := url.URL{Scheme: base.Scheme, Host: base.Host, Path: prefix + path}
+			candidates = append(candidates, u.String())
+		}
+	}
+	return candidates
+}
+
+// isFeedLinkType 判断 <link> 的 type 是否为订阅源
+func isFeedLinkType(linkType string) bool {
+	linkType = strings.ToLower(strings.TrimSpace(linkType))
+	for _, t := range feedLinkTypes {
+		if linkType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// isFeedContentType 判断响应的 Content-Type 是否为订阅源
+func isFeedContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return strings.Contains(contentType, "rss") ||
+		strings.Contains(contentType, "atom") ||
+		strings.Contains(contentType, "rdf") ||
+		(strings.Contains(contentType, "xml") && !strings.Contains(contentType, "html"))
+}
+
+// looksLikeFeed 粗略判断内容是否为 XML 订阅源
+func looksLikeFeed(body []byte) bool {
+	head := strings.ToLower(string(body[:min(len(body), 1024)]))
+	return strings.Contains(head, "<rss") ||
+		strings.Contains(head, "<feed") ||
+		strings.Contains(head, "<rdf:rdf")
+}
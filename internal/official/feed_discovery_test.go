@@ -0,0 +1,130 @@
+package official
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newDiscoveryServer 启动测试站点：pages 为路径到 HTML 页面的映射，feeds 中的路径返回订阅源
+func newDiscoveryServer(t *testing.T, pages map[string]string, feeds []string, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	feed, err := os.ReadFile(filepath.Join("testdata", "rss2.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests != nil {
+			requests.Add(1)
+		}
+		for _, path := range feeds {
+			if r.URL.Path == path {
+				w.Header().Set("Content-Type", "application/rss+xml")
+				w.Write(feed)
+				return
+			}
+		}
+		if page, ok := pages[r.URL.Path]; ok {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(page))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDiscoverLinkAlternate(t *testing.T) {
+	page := `<html><head>
+<link rel="stylesheet" href="/style.css">
+<link rel="alternate" type="text/html" href="/zh/">
+<link rel="alternate" type="application/atom+xml" href="feeds/all.atom">
+</head><body></body></html>`
+	server := newDiscoveryServer(t, map[string]string{"/blog/": page}, nil, nil)
+
+	got, err := NewFeedDiscoverer().Discover(context.Background(), server.URL+"/blog/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := server.URL + "/blog/feeds/all.atom"; got != want {
+		t.Errorf("Discover = %q, want %q", got, want)
+	}
+}
+
+func TestDiscoverCommonPaths(t *testing.T) {
+	tests := []struct {
+		name string
+		feed string
+	}{
+		{"under page path", "/blog/index.xml"},
+		{"site root", "/rss.xml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newDiscoveryServer(t, map[string]string{"/blog": "<html><body>博客</body></html>"}, []string{tt.feed}, nil)
+
+			got, err := NewFeedDiscoverer().Discover(context.Background(), server.URL+"/blog")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := server.URL + tt.feed; got != want {
+				t.Errorf("Discover = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDiscoverPageIsFeed(t *testing.T) {
+	server := newDiscoveryServer(t, nil, []string{"/feed"}, nil)
+
+	got, err := NewFeedDiscoverer().Discover(context.Background(), server.URL+"/feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := server.URL + "/feed"; got != want {
+		t.Errorf("Discover = %q, want %q", got, want)
+	}
+}
+
+func TestDiscoverNotFound(t *testing.T) {
+	var requests atomic.Int32
+	server := newDiscoveryServer(t, map[string]string{"/blog/": "<html><body>没有订阅源</body></html>"}, nil, &requests)
+
+	_, err := NewFeedDiscoverer().Discover(context.Background(), server.URL+"/blog/")
+	if err == nil || !strings.Contains(err.Error(), "未发现订阅源") {
+		t.Fatalf("Discover error = %v, want not found", err)
+	}
+	// 页面本身加上页面路径和根路径下的全部常见路径
+	if got, want := int(requests.Load()), 1+2*len(commonFeedPaths); got != want {
+		t.Errorf("requests = %d, want %d", got, want)
+	}
+}
+
+func TestDiscoverTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	discoverer := NewFeedDiscoverer()
+	discoverer.timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	_, err := discoverer.Discover(context.Background(), server.URL)
+	if err == nil || !strings.Contains(err.Error(), "超时") {
+		t.Errorf("Discover error = %v, want timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Discover took %v after the deadline", elapsed)
+	}
+}
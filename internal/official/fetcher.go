@@ -1,14 +1,121 @@
 package official
 
 import (
+	"context"
 	"fmt"
 	"news4coder/internal/search"
+	"sort"
+	"strings"
+	"sync"
 )
 
 // Fetcher 定义抓取器接口
 type Fetcher interface {
-	// Fetch 抓取内容并返回结果列表
-	Fetch() ([]search.SearchResult, error)
+	// Fetch 抓取内容并返回结果列表，ctx 取消时中止进行中的请求
+	Fetch(ctx context.Context) ([]search.SearchResult, error)
+}
+
+// FetcherConstructor 根据官方源配置创建抓取器，调用前配置已通过 ConfigSchema 校验
+type FetcherConstructor func(source *Source) (Fetcher, error)
+
+// FetcherType 抓取器类型，通过 RegisterFetcher 注册后即可在官方源的 fetcher_type 中使用
+type FetcherType struct {
+	Name        string             `json:"name"`        // 类型名称，对应 Source.FetcherType
+	Description string             `json:"description"` // 类型说明
+	Schema      ConfigSchema       `json:"schema"`      // 配置要求
+	New         FetcherConstructor `json:"-"`           // 构造函数
+}
+
+// ConfigSchema 抓取器类型的配置要求，用于在加载官方源时校验配置
+type ConfigSchema struct {
+	// Selectors 是否需要 selectors 选择器配置
+	Selectors bool `json:"selectors"`
+	// Options 支持的 options 参数，未声明的参数视为错误
+	Options []OptionSpec `json:"options,omitempty"`
+	// Validate 附加校验（可选），在通用校验通过后调用
+	Validate func(source *Source) error `json:"-"`
+}
+
+// OptionSpec options 参数说明
+type OptionSpec struct {
+	Name        string `json:"name"`        // 参数名
+	Description string `json:"description"` // 参数说明
+	Required    bool   `json:"required"`    // 是否必填
+}
+
+var (
+	fetcherTypesMu sync.RWMutex
+	fetcherTypes   = make(map[string]FetcherType)
+)
+
+// RegisterFetcher 注册抓取器类型，通常在抓取器所在包的 init 中调用，
+// 需要早于首次调用 GetRegistry（加载官方源时按已注册的类型校验配置）；
+// 类型名称为空、缺少构造函数或名称重复时 panic
+func RegisterFetcher(ft FetcherType) {
+	fetcherTypesMu.Lock()
+	defer fetcherTypesMu.Unlock()
+
+	if ft.Name == "" || strings.ContainsAny(ft.Name, " \t") {
+		panic(fmt.Sprintf("official: 抓取器类型名称无效: %q", ft.Name))
+	}
+	if ft.New == nil {
+		panic(fmt.Sprintf("official: 抓取器类型 %s 缺少构造函数", ft.Name))
+	}
+	if _, exists := fetcherTypes[ft.Name]; exists {
+		panic(fmt.Sprintf("official: 抓取器类型 %s 重复注册", ft.Name))
+	}
+	fetcherTypes[ft.Name] = ft
+}
+
+// LookupFetcher 根据名称查找已注册的抓取器类型
+func LookupFetcher(name string) (FetcherType, bool) {
+	fetcherTypesMu.RLock()
+	defer fetcherTypesMu.RUnlock()
+	ft, ok := fetcherTypes[name]
+	return ft, ok
+}
+
+// FetcherTypes 返回所有已注册的抓取器类型，按名称排序
+func FetcherTypes() []FetcherType {
+	fetcherTypesMu.RLock()
+	defer fetcherTypesMu.RUnlock()
+
+	types := make([]FetcherType, 0, len(fetcherTypes))
+	for _, ft := range fetcherTypes {
+		types = append(types, ft)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+	return types
+}
+
+// Check 按配置要求校验官方源
+func (s ConfigSchema) Check(source *Source) error {
+	if s.Selectors && source.Selectors == nil {
+		return fmt.Errorf("缺少选择器配置（selectors）")
+	}
+	if !s.Selectors && source.Selectors != nil {
+		return fmt.Errorf("抓取器类型 %s 不使用选择器配置（selectors）", source.FetcherType)
+	}
+
+	known := make(map[string]bool)
+	for _, opt := range s.Options {
+		known[opt.Name] = true
+		if opt.Required && strings.TrimSpace(source.Options[opt.Name]) == "" {
+			return fmt.Errorf("缺少参数 options.%s", opt.Name)
+		}
+	}
+	for name := range source.Options {
+		if !known[name] {
+			return fmt.Errorf("抓取器类型 %s 不支持参数 options.%s", source.FetcherType, name)
+		}
+	}
+
+	if s.Validate != nil {
+		return s.Validate(source)
+	}
+	return nil
 }
 
 // FetcherFactory 抓取器工厂，根据类型创建对应的抓取器实例
@@ -21,10 +128,12 @@ func NewFetcherFactory() *FetcherFactory {
 
 // Create 根据官方源配置创建对应的抓取器
 func (f *FetcherFactory) Create(source *Source) (Fetcher, error) {
-	switch source.FetcherType {
-	case "infoq":
-		return NewInfoQFetcher(source.URL), nil
-	default:
+	ft, ok := LookupFetcher(source.FetcherType)
+	if !ok {
 		return nil, fmt.Errorf("不支持的抓取器类型: %s", source.FetcherType)
 	}
+	if err := ft.Schema.Check(source); err != nil {
+		return nil, fmt.Errorf("官方源 %s 的配置无效: %w", source.Alias, err)
+	}
+	return ft.New(source)
 }
@@ -1,10 +1,14 @@
 package official
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"news4coder/internal/search"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,23 +18,162 @@ import (
 // InfoQFetcher InfoQ 热点清单抓取器
 type InfoQFetcher struct {
 	url    string
+	apiURL string
 	client *http.Client
 }
 
+func init() {
+	RegisterFetcher(FetcherType{
+		Name:        "infoq",
+		Description: "InfoQ 热点清单专用抓取器",
+		New: func(source *Source) (Fetcher, error) {
+			return NewInfoQFetcher(source.URL), nil
+		},
+	})
+}
+
 // NewInfoQFetcher 创建 InfoQ 抓取器实例
 func NewInfoQFetcher(url string) *InfoQFetcher {
 	return &InfoQFetcher{
-		url: url,
+		url:    url,
+		apiURL: infoqHotListAPI,
 		client: &http.Client{
 			Timeout: 15 * time.Second,
 		},
 	}
 }
 
+// infoqHotListAPI 热点清单页面（SPA）加载数据时调用的接口，
+// 请求体为 infoqHotListRequest，响应的 data 数组按热度顺序列出文章
+const infoqHotListAPI = "https://www.infoq.cn/public/v1/article/getHotList"
+
+// infoqHotListRequest 热点清单接口的请求体
+const infoqHotListRequest = `{"type":1,"size":10}`
+
+// infoqMaxResults 最多返回的文章数
+const infoqMaxResults = 10
+
+// infoqBaseURL 补全相对链接的基础地址
+const infoqBaseURL = "https://www.infoq.cn/"
+
+// infoqArticleSelectors 热点清单页面文章列表项内的通用选择器
+var infoqArticleSelectors = SelectorConfig{
+	Title:   "h2, h3, h4, .title, .article-title, a",
+	Link:    "a",
+	Snippet: ".summary, .description, .excerpt, p",
+	Date:    ".date, .time, .publish-time",
+}
+
+// infoqPageSelectors 热点清单页面有静态内容时依次尝试的选择器
+var infoqPageSelectors = []SelectorConfig{
+	withItem(infoqArticleSelectors, ".article-list .article-item"), // 主选择器
+	withItem(infoqArticleSelectors, ".hot-list .hot-item"),         // 备选选择器1
+	withItem(infoqArticleSelectors, ".list-item"),                  // 备选选择器2
+	withItem(infoqArticleSelectors, "article"),                     // 备选选择器3
+	withItem(infoqArticleSelectors, ".content-list > div"),         // 备选选择器4
+	{Item: "a[href*='/article/'], a[href*='/news/']"},              // 直接从文章链接提取
+}
+
+// withItem 返回指定列表项选择器的配置副本
+func withItem(config SelectorConfig, item string) SelectorConfig {
+	config.Item = item
+	return config
+}
+
+// infoqStateMarkers 页面内嵌初始数据的全局变量名
+var infoqStateMarkers = []string{
+	"window.__INITIAL_STATE__",
+	"window.__NUXT__",
+	"window.__PRELOADED_STATE__",
+}
+
 // Fetch 抓取 InfoQ 热点清单内容
-func (f *InfoQFetcher) Fetch() ([]search.SearchResult, error) {
+//
+// 优先调用热点清单页面使用的 JSON 接口，失败时回退到解析页面内嵌的初始数据
+// 或静态内容。所有方式都失败时返回错误，不会用演示数据代替。
+func (f *InfoQFetcher) Fetch(ctx context.Context) ([]search.SearchResult, error) {
+	results, apiErr := f.fetchFromAPI(ctx)
+	if apiErr == nil {
+		return results, nil
+	}
+
+	// 已取消时不再尝试页面解析
+	if ctx.Err() != nil {
+		return nil, apiErr
+	}
+
+	results, pageErr := f.fetchFromPage(ctx)
+	if pageErr == nil {
+		return results, nil
+	}
+
+	return nil, fmt.Errorf("InfoQ 热点清单提取失败\n  接口: %v\n  页面: %v\n\n建议:\n1. 检查网络连接\n2. 使用 --demo 参数查看演示效果\n3. 直接访问: %s", apiErr, pageErr, f.url)
+}
+
+// fetchFromAPI 通过热点清单 JSON 接口获取文章列表
+func (f *InfoQFetcher) fetchFromAPI(ctx context.Context) ([]search.SearchResult, error) {
+	body := strings.NewReader(infoqHotListRequest)
+	req, err := http.NewRequestWithContext(ctx, "POST", f.apiURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	// 接口会校验来源页面
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "application/json, text/plain, */*")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Origin", "https://www.infoq.cn")
+	req.Header.Set("Referer", f.url)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("网络请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	return parseHotListResponse(resp.Body)
+}
+
+// parseHotListResponse 解析热点清单接口的响应，按 data 数组的顺序提取文章
+func parseHotListResponse(r io.Reader) ([]search.SearchResult, error) {
+	var response struct {
+		Code int             `json:"code"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(r).Decode(&response); err != nil {
+		return nil, fmt.Errorf("JSON解析失败: %w", err)
+	}
+	if response.Code != 0 {
+		return nil, fmt.Errorf("接口返回错误码: %d", response.Code)
+	}
+
+	// data 通常是文章数组，部分版本包装为 {"list": [...]}
+	var items []map[string]any
+	if err := json.Unmarshal(response.Data, &items); err != nil {
+		var wrapped struct {
+			List []map[string]any `json:"list"`
+		}
+		if err := json.Unmarshal(response.Data, &wrapped); err != nil {
+			return nil, fmt.Errorf("接口数据格式无法识别: %w", err)
+		}
+		items = wrapped.List
+	}
+
+	results := articlesFromList(items)
+	if len(results) == 0 {
+		return nil, fmt.Errorf("接口未返回文章数据")
+	}
+	return results, nil
+}
+
+// fetchFromPage 抓取热点清单页面，从内嵌初始数据或静态内容中提取文章列表
+func (f *InfoQFetcher) fetchFromPage(ctx context.Context) ([]search.SearchResult, error) {
 	// 发送 HTTP 请求
-	req, err := http.NewRequest("GET", f.url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", f.url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -42,12 +185,12 @@ func (f *InfoQFetcher) Fetch() ([]search.SearchResult, error) {
 
 	resp, err := f.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("网络请求失败: %w\n\n建议:\n1. 检查网络连接\n2. 直接访问: %s", err, f.url)
+		return nil, fmt.Errorf("网络请求失败: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("请求失败，状态码: %d\n\n建议:\n直接访问: %s", resp.StatusCode, f.url)
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
 	}
 
 	// 解析 HTML
@@ -58,206 +201,250 @@ func (f *InfoQFetcher) Fetch() ([]search.SearchResult, error) {
 
 	// 提取文章列表
 	results, err := f.parseResults(doc)
+	if err == nil && len(results) == 0 {
+		err = fmt.Errorf("未找到内容，页面结构可能已变更")
+	}
 	if err != nil {
-		// 调试模式：保存HTML到文件
+		// 调试模式：保存HTML到文件，提示输出到标准错误
 		if os.Getenv("DEBUG_OFFICIAL") == "1" {
-			htmlContent, _ := doc.Html()
-			os.WriteFile("debug_infoq.html", []byte(htmlContent), 0644)
-			fmt.Println("已保存HTML到 debug_infoq.html")
+			htmlContent, htmlErr := doc.Html()
+			if htmlErr == nil {
+				htmlErr = os.WriteFile("debug_infoq.html", []byte(htmlContent), 0644)
+			}
+			if htmlErr != nil {
+				fmt.Fprintf(os.Stderr, "保存调试页面 debug_infoq.html 失败: %v\n", htmlErr)
+			} else {
+				fmt.Fprintln(os.Stderr, "已保存HTML到 debug_infoq.html")
+			}
 		}
 		return nil, err
 	}
 
-	if len(results) == 0 {
-		return nil, fmt.Errorf("未找到内容\n\n可能原因:\n1. 页面结构已变更\n2. 页面暂无内容\n\n建议:\n访问原页面: %s", f.url)
-	}
-
 	return results, nil
 }
 
 // parseResults 解析 HTML 提取文章列表
 func (f *InfoQFetcher) parseResults(doc *goquery.Document) ([]search.SearchResult, error) {
-	var results []search.SearchResult
-	index := 1
+	// InfoQ 热点清单页面使用 JavaScript 动态渲染，优先读取内嵌的初始数据
+	if state, ok := extractEmbeddedState(doc); ok {
+		if results := articlesFromList(findArticleList(state)); len(results) > 0 {
+			return results, nil
+		}
+	}
 
-	// InfoQ 热点清单页面使用 JavaScript 动态渲染
 	// 检查页面是否为空（只有 <div id="app"></div>）
 	appDiv := doc.Find("#app")
 	if appDiv.Length() > 0 && strings.TrimSpace(appDiv.Text()) == "" {
-		// 页面是 SPA，需要其他方法
-		// 这里提供演示数据
-		if os.Getenv("DEMO_MODE") == "1" || true {
-			// 使用演示数据
-			return f.generateDemoResults(), nil
-		}
-		return nil, fmt.Errorf("InfoQ 页面使用 JavaScript 动态渲染，无法直接抓取\n\n建议:\n1. 访问原页面: %s\n2. 等待工具更新支持", f.url)
+		return nil, fmt.Errorf("页面使用 JavaScript 动态渲染，且未包含可解析的初始数据")
 	}
 
-	// InfoQ 热点清单页面的选择器（如果页面有静态内容）
-	// 主选择器：尝试查找文章列表容器
-	selectors := []string{
-		".article-list .article-item", // 主选择器
-		".hot-list .hot-item",         // 备选选择器1
-		".list-item",                  // 备选选择器2
-		"article",                     // 备选选择器3
-		".content-list > div",         // 备选选择器4
+	// 页面有静态内容时，依次尝试各组选择器，使用第一组能匹配到列表项的
+	for _, config := range infoqPageSelectors {
+		if doc.Find(config.Item).Length() > 0 {
+			return ParseWithSelectors(doc, config, infoqBaseURL), nil
+		}
 	}
 
-	var selection *goquery.Selection
-	var usedSelector string
+	return nil, fmt.Errorf("页面结构可能已变更，无法定位文章列表")
+}
 
-	// 尝试多个选择器
-	for _, selector := range selectors {
-		selection = doc.Find(selector)
-		if selection.Length() > 0 {
-			usedSelector = selector
-			break
+// extractEmbeddedState 提取页面脚本中内嵌的初始数据（如 window.__INITIAL_STATE__）
+func extractEmbeddedState(doc *goquery.Document) (any, bool) {
+	// Next.js 页面将初始数据放在独立的 JSON 脚本中
+	if text := strings.TrimSpace(doc.Find("script#__NEXT_DATA__").Text()); text != "" {
+		var state any
+		if err := json.Unmarshal([]byte(text), &state); err == nil {
+			return state, true
 		}
 	}
 
-	if selection == nil || selection.Length() == 0 {
-		// 尝试通用的 a 标签选择器
-		selection = doc.Find("a[href*='/article/'], a[href*='/news/']")
-		usedSelector = "a[href*='/article/']"
-	}
+	var state any
+	found := false
+	doc.Find("script").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		text := s.Text()
+		for _, marker := range infoqStateMarkers {
+			idx := strings.Index(text, marker)
+			if idx < 0 {
+				continue
+			}
+			object := extractJSONObject(text[idx+len(marker):])
+			if object == "" {
+				continue
+			}
+			if err := json.Unmarshal([]byte(object), &state); err == nil {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return state, found
+}
 
-	if selection == nil || selection.Length() == 0 {
-		return nil, fmt.Errorf("页面结构可能已变更，无法定位文章列表")
+// extractJSONObject 从文本中截取第一个完整的 JSON 对象
+func extractJSONObject(text string) string {
+	start := strings.Index(text, "{")
+	if start < 0 {
+		return ""
 	}
 
-	// 遍历文章项
-	selection.Each(func(i int, s *goquery.Selection) {
-		if index > 10 {
-			return
+	depth := 0
+	inString := false
+	escaped := false
+	for i := start; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return text[start : i+1]
+			}
 		}
+	}
+	return ""
+}
 
-		result := search.SearchResult{Index: index}
+// articlesFromList 按数组顺序将文章对象转换为结果（最多 infoqMaxResults 条），跳过无效和重复的条目
+func articlesFromList(items []map[string]any) []search.SearchResult {
+	var results []search.SearchResult
+	seen := make(map[string]bool)
+	for _, item := range items {
+		result, ok := articleFromJSON(item)
+		if !ok || seen[result.URL] {
+			continue
+		}
+		seen[result.URL] = true
+		result.Index = len(results) + 1
+		results = append(results, result)
+		if len(results) >= infoqMaxResults {
+			break
+		}
+	}
+	return results
+}
 
-		// 根据不同的选择器提取内容
-		if usedSelector == "a[href*='/article/']" {
-			// 直接从链接提取
-			result.Title = strings.TrimSpace(s.Text())
-			if href, exists := s.Attr("href"); exists {
-				result.URL = f.normalizeURL(href)
+// findArticleList 在页面初始数据中查找文章列表：按键名排序深度优先遍历，
+// 返回第一个元素全部为文章对象的数组，结果与 map 遍历顺序无关
+func findArticleList(node any) []map[string]any {
+	switch v := node.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if list := findArticleList(v[key]); list != nil {
+				return list
 			}
-		} else {
-			// 从文章项中查找标题和链接
-			titleElem := s.Find("h2, h3, h4, .title, .article-title, a")
-			if titleElem.Length() > 0 {
-				result.Title = strings.TrimSpace(titleElem.First().Text())
-
-				// 查找链接
-				linkElem := s.Find("a").First()
-				if linkElem.Length() > 0 {
-					if href, exists := linkElem.Attr("href"); exists {
-						result.URL = f.normalizeURL(href)
-					}
-				}
+		}
+	case []any:
+		if list, ok := asArticleList(v); ok {
+			return list
+		}
+		for _, child := range v {
+			if list := findArticleList(child); list != nil {
+				return list
 			}
+		}
+	}
+	return nil
+}
 
-			// 提取摘要
-			snippetElem := s.Find(".summary, .description, .excerpt, p")
-			if snippetElem.Length() > 0 {
-				result.Snippet = strings.TrimSpace(snippetElem.First().Text())
-			}
+// asArticleList 判断数组是否为文章列表（非空且每个元素都是带文章标识的对象）
+func asArticleList(items []any) ([]map[string]any, bool) {
+	if len(items) == 0 {
+		return nil, false
+	}
+	list := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok || !isArticleObject(obj) {
+			return nil, false
 		}
+		list = append(list, obj)
+	}
+	return list, true
+}
 
-		// 只添加有效的结果（至少有标题和URL）
-		if result.Title != "" && result.URL != "" {
-			// 清理标题中的多余空白
-			result.Title = strings.Join(strings.Fields(result.Title), " ")
+// isArticleObject 判断对象是否为 InfoQ 文章：需要文章标题和文章标识（uuid 或文章链接），
+// 作者、话题等同样带 title 的对象不符合
+func isArticleObject(obj map[string]any) bool {
+	if firstString(obj, "article_title", "title") == "" {
+		return false
+	}
+	if firstString(obj, "uuid", "article_uuid") != "" {
+		return true
+	}
+	link := firstString(obj, "article_url")
+	if link == "" {
+		link = firstString(obj, "url", "link")
+	}
+	return strings.Contains(link, "/article/") || strings.Contains(link, "/news/")
+}
 
-			// 截断过长的摘要
-			if len(result.Snippet) > 200 {
-				result.Snippet = result.Snippet[:200] + "..."
-			}
+// articleFromJSON 将 InfoQ 文章 JSON 对象转换为结果
+func articleFromJSON(obj map[string]any) (search.SearchResult, bool) {
+	if !isArticleObject(obj) {
+		return search.SearchResult{}, false
+	}
+	title := firstString(obj, "article_title", "title")
 
-			results = append(results, result)
-			index++
-		}
-	})
+	link := firstString(obj, "article_url", "url", "link")
+	if link != "" {
+		link = resolveLink(infoqBaseURL, link)
+	} else if uuid := firstString(obj, "uuid", "article_uuid"); uuid != "" {
+		link = "https://www.infoq.cn/article/" + uuid
+	} else {
+		return search.SearchResult{}, false
+	}
 
-	return results, nil
-}
+	result := search.SearchResult{
+		Title:   strings.Join(strings.Fields(title), " "),
+		URL:     link,
+		Snippet: strings.TrimSpace(firstString(obj, "article_summary", "summary", "description")),
+	}
 
-// generateDemoResults 生成演示数据
-func (f *InfoQFetcher) generateDemoResults() []search.SearchResult {
-	// 演示数据链接到 InfoQ 热点清单页面
-	hotlistURL := "https://www.infoq.cn/hotlist"
-	return []search.SearchResult{
-		{
-			Index:   1,
-			Title:   "2025年技术趋势：AI原生应用的崛起",
-			URL:     hotlistURL,
-			Snippet: "随着大语言模型的不断进化，AI原生应用正在改变软件开发的范式。本文探讨2025年AI技术的发展趋势和最佳实践。",
-		},
-		{
-			Index:   2,
-			Title:   "Kubernetes 1.30 新特性深度解析",
-			URL:     hotlistURL,
-			Snippet: "Kubernetes 最新版本带来了更强大的容器编排能力和安全性增强，包括改进的资源管理和新的调度策略。",
-		},
-		{
-			Index:   3,
-			Title:   "Go 1.24 泛型最佳实践",
-			URL:     hotlistURL,
-			Snippet: "深入探讨 Go 语言泛型的使用场景和最佳实践，帮助开发者写出更优雅、更高效的代码。",
-		},
-		{
-			Index:   4,
-			Title:   "微服务架构下的可观测性实践",
-			URL:     hotlistURL,
-			Snippet: "在复杂的微服务架构中，如何构建完善的可观测性体系？本文分享一线大厂的实践经验。",
-		},
-		{
-			Index:   5,
-			Title:   "前端性能优化：从60fps到120fps",
-			URL:     hotlistURL,
-			Snippet: "随着高刷新率屏幕的普及，前端性能优化面临新挑战。本文介绍如何实现流畅的120fps体验。",
-		},
-		{
-			Index:   6,
-			Title:   "Rust 在云原生领域的应用",
-			URL:     hotlistURL,
-			Snippet: "Rust 凭借其内存安全和高性能特性，正在云原生领域崭露头角。探索 Rust 在容器运行时和服务网格中的应用。",
-		},
-		{
-			Index:   7,
-			Title:   "数据库选型指南：2025版",
-			URL:     hotlistURL,
-			Snippet: "面对众多数据库产品，如何根据业务场景做出正确选择？本文提供全面的数据库选型指南。",
-		},
-		{
-			Index:   8,
-			Title:   "大规模分布式系统的一致性保证",
-			URL:     hotlistURL,
-			Snippet: "深入分析分布式系统中的一致性问题，介绍 Paxos、Raft 等共识算法的实际应用。",
-		},
-		{
-			Index:   9,
-			Title:   "eBPF：Linux内核可编程的未来",
-			URL:     hotlistURL,
-			Snippet: "eBPF 技术正在改变我们与 Linux 内核的交互方式，为性能监控、安全和网络带来革命性变化。",
-		},
-		{
-			Index:   10,
-			Title:   "DevOps 2025：平台工程的崛起",
-			URL:     hotlistURL,
-			Snippet: "平台工程正在成为 DevOps 演进的下一个阶段，了解如何构建开发者友好的内部平台。",
-		},
+	// 截断过长的摘要
+	if runes := []rune(result.Snippet); len(runes) > 200 {
+		result.Snippet = string(runes[:200]) + "..."
 	}
-}
 
-// normalizeURL 规范化 URL
-func (f *InfoQFetcher) normalizeURL(href string) string {
-	// 如果是相对路径，补全为绝对路径
-	if strings.HasPrefix(href, "/") {
-		return "https://www.infoq.cn" + href
+	// 发布时间可能是毫秒时间戳、秒时间戳或日期字符串
+	for _, key := range []string{"publish_time", "publishTime", "ctime"} {
+		switch v := obj[key].(type) {
+		case float64:
+			if v > 1e12 {
+				result.PublishedDate = time.UnixMilli(int64(v))
+			} else if v > 0 {
+				result.PublishedDate = time.Unix(int64(v), 0)
+			}
+		case string:
+			result.PublishedDate, _ = search.ParseDate(v)
+		}
+		if !result.PublishedDate.IsZero() {
+			break
+		}
 	}
-	// 如果是完整URL，直接返回
-	if strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
-		return href
+
+	return result, true
+}
+
+// firstString 返回第一个非空的字符串字段
+func firstString(obj map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := obj[key].(string); ok && strings.TrimSpace(v) != "" {
+			return v
+		}
 	}
-	// 其他情况，拼接基础URL
-	return "https://www.infoq.cn/" + href
+	return ""
 }
@@ -0,0 +1,147 @@
+package official
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// testdata/infoq_hotlist.json 按热点清单接口的响应结构整理：data 数组中的文章带有
+// author、topic 等同样含 title 的嵌套对象，以及无标题、重复和相对链接的条目
+func TestParseHotListResponse(t *testing.T) {
+	file, err := os.Open(filepath.Join("testdata", "infoq_hotlist.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	results, err := parseHotListResponse(file)
+	if err != nil {
+		t.Fatalf("parseHotListResponse: %v", err)
+	}
+
+	want := []struct {
+		title string
+		url   string
+		date  time.Time
+	}{
+		{"Go 1.23 新特性详解", "https://www.infoq.cn/article/Xk2ZpM9rT1aB", time.UnixMilli(1723514400000)},
+		{"微服务架构下的分布式事务实践", "https://www.infoq.cn/article/Qw8sLm2NcVbx", time.Unix(1723428000, 0)},
+		{"Kubernetes 1.31 新功能一览", "https://www.infoq.cn/news/k8s-131", time.Date(2024, 8, 10, 9, 0, 0, 0, time.Local)},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Index != i+1 || r.Title != w.title || r.URL != w.url {
+			t.Errorf("[%d] got (%d, %q, %q), want (%d, %q, %q)", i, r.Index, r.Title, r.URL, i+1, w.title, w.url)
+		}
+		if !r.PublishedDate.Equal(w.date) {
+			t.Errorf("[%d] PublishedDate = %v, want %v", i, r.PublishedDate, w.date)
+		}
+	}
+	if results[0].Snippet == "" {
+		t.Error("summary missing for first article")
+	}
+}
+
+func TestParseHotListResponseErrors(t *testing.T) {
+	tests := map[string]string{
+		"error code":  `{"code":403,"data":null}`,
+		"empty data":  `{"code":0,"data":[]}`,
+		"no articles": `{"code":0,"data":[{"nickname":"作者","title":"头衔"}]}`,
+		"bad json":    `<html>`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseHotListResponse(strings.NewReader(body)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestParseHotListResponseWrappedList(t *testing.T) {
+	body := `{"code":0,"data":{"list":[{"uuid":"a","article_title":"A"},{"uuid":"b","article_title":"B"}]}}`
+	results, err := parseHotListResponse(strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Title != "A" || results[1].Title != "B" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestInfoQFetcherAPIRequest(t *testing.T) {
+	fixture, err := os.ReadFile(filepath.Join("testdata", "infoq_hotlist.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if string(body) != infoqHotListRequest {
+			t.Errorf("body = %s, want %s", body, infoqHotListRequest)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q", got)
+		}
+		if got := r.Header.Get("Referer"); got != "https://www.infoq.cn/hotlist" {
+			t.Errorf("Referer = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	fetcher := NewInfoQFetcher("https://www.infoq.cn/hotlist")
+	fetcher.apiURL = server.URL
+	results, err := fetcher.fetchFromAPI(context.Background())
+	if err != nil {
+		t.Fatalf("fetchFromAPI: %v", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("got %d results, want 3", len(results))
+	}
+}
+
+func TestParseResultsEmbeddedState(t *testing.T) {
+	file, err := os.Open(filepath.Join("testdata", "infoq_state.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	doc, err := goquery.NewDocumentFromReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 多次解析结果一致，且不包含广告、作者等对象
+	for i := 0; i < 20; i++ {
+		results, err := NewInfoQFetcher("https://www.infoq.cn/hotlist").parseResults(doc)
+		if err != nil {
+			t.Fatalf("parseResults: %v", err)
+		}
+		var titles []string
+		for _, r := range results {
+			titles = append(titles, r.Title)
+		}
+		got := strings.Join(titles, "|")
+		if want := `第一篇|第二篇 "引号" 与 {括号}|第三篇`; got != want {
+			t.Fatalf("titles = %s, want %s", got, want)
+		}
+	}
+}
@@ -2,10 +2,16 @@ package official
 
 // Source 表示一个官方新闻源
 type Source struct {
-	Alias       string // 唯一别名，用于命令行调用
-	Name        string // 官方源显示名称
-	URL         string // 目标页面完整URL
-	FetcherType string // 抓取器类型标识
-	Description string // 官方源简介
-	Enabled     bool   // 是否启用
+	Alias       string `json:"alias"`        // 唯一别名，用于命令行调用
+	Name        string `json:"name"`         // 官方源显示名称
+	URL         string `json:"url"`          // 目标页面完整URL
+	FetcherType string `json:"fetcher_type"` // 抓取器类型标识
+	Description string `json:"description"`  // 官方源简介
+	Enabled     bool   `json:"enabled"`      // 是否启用
+	Origin      string `json:"origin"`       // 定义来源：builtin、user 或 override
+
+	// Selectors 选择器抓取规则，仅 FetcherType 为 "selector" 时使用
+	Selectors *SelectorConfig `json:"selectors,omitempty"`
+	// Options 抓取器附加参数，支持的参数由抓取器类型的 ConfigSchema 声明
+	Options map[string]string `json:"options,omitempty"`
 }
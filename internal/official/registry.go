@@ -1,6 +1,29 @@
 package official
 
-import "sync"
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"news4coder/internal/storage"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 官方源的来源
+const (
+	OriginBuiltin  = "builtin"  // 内置
+	OriginUser     = "user"     // 用户文件新增
+	OriginOverride = "override" // 内置，被用户文件覆盖
+)
+
+// userSourcesFile 用户官方源文件名，位于配置目录下
+const userSourcesFile = "sources.json"
+
+//go:embed sources.json
+var builtinSources []byte
 
 var (
 	registry *Registry
@@ -9,32 +32,187 @@ var (
 
 // Registry 官方源注册表，管理所有官方新闻源
 type Registry struct {
-	sources map[string]*Source // key 为别名
+	sources  map[string]*Source // key 为别名
+	warnings []string
+}
+
+// sourcesFile 官方源定义文件结构（内置文件与用户文件共用）
+type sourcesFile struct {
+	Sources []sourceEntry `json:"sources"`
+}
+
+// sourceEntry 官方源定义，字段为空表示不覆盖内置定义
+type sourceEntry struct {
+	Alias       string            `json:"alias"`
+	Name        *string           `json:"name"`
+	URL         *string           `json:"url"`
+	FetcherType *string           `json:"fetcher_type"`
+	Description *string           `json:"description"`
+	Enabled     *bool             `json:"enabled"`
+	Selectors   *SelectorConfig   `json:"selectors"`
+	Options     map[string]string `json:"options"`
 }
 
 // GetRegistry 获取官方源注册表单例
 func GetRegistry() *Registry {
 	once.Do(func() {
-		registry = &Registry{
-			sources: make(map[string]*Source),
+		userPath, err := UserSourcesPath()
+		if err != nil {
+			userPath = ""
 		}
-		// 注册官方源
-		registry.registerDefaultSources()
+		registry = newRegistry(userPath)
 	})
 	return registry
 }
 
-// registerDefaultSources 注册默认的官方源
-func (r *Registry) registerDefaultSources() {
-	// InfoQ 中文站热点清单
-	r.sources["infoq"] = &Source{
-		Alias:       "infoq",
-		Name:        "InfoQ 中文站热点清单",
-		URL:         "https://www.infoq.cn/hotlist",
-		FetcherType: "infoq",
-		Description: "InfoQ 中文站的热点文章列表",
-		Enabled:     true,
+// newRegistry 创建注册表：加载内置官方源，再合并 userPath 中用户定义的官方源（为空时跳过）
+func newRegistry(userPath string) *Registry {
+	r := &Registry{
+		sources: make(map[string]*Source),
+	}
+	// 注册内置官方源
+	if err := r.loadBuiltinSources(); err != nil {
+		panic(err)
+	}
+	// 合并用户定义的官方源
+	if userPath != "" {
+		r.loadUserSources(userPath)
+	}
+	return r
+}
+
+// UserSourcesPath 返回用户官方源文件路径（配置目录下的 sources.json）
+func UserSourcesPath() (string, error) {
+	dir, err := storage.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, userSourcesFile), nil
+}
+
+// loadBuiltinSources 加载内置的官方源定义
+func (r *Registry) loadBuiltinSources() error {
+	var file sourcesFile
+	if err := json.Unmarshal(builtinSources, &file); err != nil {
+		return fmt.Errorf("内置官方源定义格式错误: %w", err)
+	}
+	for _, entry := range file.Sources {
+		source := &Source{Alias: entry.Alias, Enabled: true, Origin: OriginBuiltin}
+		entry.applyTo(source)
+		r.sources[source.Alias] = source
+	}
+	return nil
+}
+
+// loadUserSources 加载用户官方源文件：别名与内置源相同时覆盖其字段，否则新增官方源。
+// 文件有误时记录警告并跳过，不影响内置官方源
+func (r *Registry) loadUserSources(path string) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		r.warn("无法读取 %s: %v", path, err)
+		return
+	}
+
+	var file sourcesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		r.warn("%s 格式错误: %v", path, err)
+		return
+	}
+
+	for _, entry := range file.Sources {
+		alias := strings.TrimSpace(entry.Alias)
+		if alias == "" || strings.Contains(alias, " ") {
+			r.warn("%s: 官方源别名无效: %q", path, entry.Alias)
+			continue
+		}
+
+		if existing, ok := r.sources[alias]; ok {
+			// 在副本上修改，校验失败时保留原定义
+			source := *existing
+			entry.applyTo(&source)
+			if err := validateSource(&source); err != nil {
+				r.warn("%s: 官方源 %s 的覆盖无效: %v", path, alias, err)
+				continue
+			}
+			if source.Origin == OriginBuiltin {
+				source.Origin = OriginOverride
+			}
+			r.sources[alias] = &source
+			continue
+		}
+
+		source := &Source{Alias: alias, Enabled: true, Origin: OriginUser}
+		entry.applyTo(source)
+		if err := validateSource(source); err != nil {
+			r.warn("%s: 官方源 %s 无效: %v", path, alias, err)
+			continue
+		}
+		r.sources[alias] = source
+	}
+}
+
+// applyTo 将定义中出现的字段写入官方源
+func (e sourceEntry) applyTo(source *Source) {
+	if e.Name != nil {
+		source.Name = *e.Name
+	}
+	if e.URL != nil {
+		source.URL = *e.URL
+	}
+	if e.FetcherType != nil {
+		source.FetcherType = *e.FetcherType
+	}
+	if e.Description != nil {
+		source.Description = *e.Description
+	}
+	if e.Enabled != nil {
+		source.Enabled = *e.Enabled
+	}
+	if e.Selectors != nil {
+		source.Selectors = e.Selectors
+	}
+	if e.Options != nil {
+		// 按参数名合并，不修改原定义共用的 map
+		options := make(map[string]string, len(source.Options)+len(e.Options))
+		for name, value := range source.Options {
+			options[name] = value
+		}
+		for name, value := range e.Options {
+			options[name] = value
+		}
+		source.Options = options
+	}
+}
+
+// validateSource 校验官方源的必填字段和抓取规则
+func validateSource(source *Source) error {
+	if strings.TrimSpace(source.Name) == "" {
+		return fmt.Errorf("缺少名称（name）")
 	}
+	if !strings.HasPrefix(source.URL, "http://") && !strings.HasPrefix(source.URL, "https://") {
+		return fmt.Errorf("URL必须是HTTP或HTTPS协议: %q", source.URL)
+	}
+	if source.FetcherType == "" {
+		return fmt.Errorf("缺少抓取器类型（fetcher_type）")
+	}
+	ft, ok := LookupFetcher(source.FetcherType)
+	if !ok {
+		return fmt.Errorf("不支持的抓取器类型: %s", source.FetcherType)
+	}
+	return ft.Schema.Check(source)
+}
+
+// warn 记录加载过程中的警告
+func (r *Registry) warn(format string, args ...any) {
+	r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+// Warnings 返回加载用户官方源文件时产生的警告
+func (r *Registry) Warnings() []string {
+	return r.warnings
 }
 
 // Get 根据别名获取官方源
@@ -46,13 +224,25 @@ func (r *Registry) Get(alias string) (*Source, bool) {
 	return source, true
 }
 
-// List 获取所有启用的官方源列表
+// List 获取所有启用的官方源列表，按别名排序
 func (r *Registry) List() []*Source {
 	var sources []*Source
-	for _, source := range r.sources {
+	for _, source := range r.All() {
 		if source.Enabled {
 			sources = append(sources, source)
 		}
 	}
 	return sources
 }
+
+// All 获取所有官方源（包括已停用的），按别名排序
+func (r *Registry) All() []*Source {
+	var sources []*Source
+	for _, source := range r.sources {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Alias < sources[j].Alias
+	})
+	return sources
+}
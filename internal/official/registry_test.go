@@ -0,0 +1,182 @@
+package official
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"news4coder/internal/search"
+)
+
+// registryTestFetcher 注册表测试用抓取器，声明了两个可选参数
+type registryTestFetcher struct{}
+
+func (registryTestFetcher) Fetch(ctx context.Context) ([]search.SearchResult, error) {
+	return nil, nil
+}
+
+func init() {
+	RegisterFetcher(FetcherType{
+		Name:        "registry-test",
+		Description: "注册表测试用抓取器",
+		Schema: ConfigSchema{
+			Options: []OptionSpec{{Name: "lang"}, {Name: "region"}},
+		},
+		New: func(source *Source) (Fetcher, error) {
+			return registryTestFetcher{}, nil
+		},
+	})
+}
+
+// loadTestRegistry 将 content 写入临时配置目录下的 sources.json 并创建注册表
+func loadTestRegistry(t *testing.T, content string) *Registry {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), userSourcesFile)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return newRegistry(path)
+}
+
+func TestRegistryBuiltinOnly(t *testing.T) {
+	r := newRegistry(filepath.Join(t.TempDir(), userSourcesFile)) // 文件不存在
+	source, ok := r.Get("infoq")
+	if !ok {
+		t.Fatal("builtin infoq missing")
+	}
+	if source.Origin != OriginBuiltin || source.FetcherType != "infoq" || !source.Enabled {
+		t.Errorf("infoq = %+v", source)
+	}
+	if len(r.Warnings()) != 0 {
+		t.Errorf("warnings = %q", r.Warnings())
+	}
+}
+
+func TestRegistryOverrideMergesFields(t *testing.T) {
+	r := loadTestRegistry(t, `{"sources": [
+		{"alias": "infoq", "url": "https://www.infoq.cn/hotlist?lang=en"}
+	]}`)
+
+	source, ok := r.Get("infoq")
+	if !ok {
+		t.Fatal("infoq missing")
+	}
+	if source.URL != "https://www.infoq.cn/hotlist?lang=en" {
+		t.Errorf("URL = %q, want overridden", source.URL)
+	}
+	// 未出现的字段保留内置定义
+	if source.Name != "InfoQ 中文站热点清单" || source.FetcherType != "infoq" || source.Description == "" {
+		t.Errorf("builtin fields lost: %+v", source)
+	}
+	if source.Origin != OriginOverride {
+		t.Errorf("Origin = %q, want %q", source.Origin, OriginOverride)
+	}
+
+	// 内置定义不受其他注册表的覆盖影响
+	if builtin, _ := newRegistry("").Get("infoq"); builtin.URL != "https://www.infoq.cn/hotlist" {
+		t.Errorf("override leaked into builtin definition: %q", builtin.URL)
+	}
+}
+
+func TestRegistryDisable(t *testing.T) {
+	r := loadTestRegistry(t, `{"sources": [{"alias": "infoq", "enabled": false}]}`)
+
+	if _, ok := r.Get("infoq"); ok {
+		t.Error("disabled source returned by Get")
+	}
+	for _, source := range r.List() {
+		if source.Alias == "infoq" {
+			t.Error("disabled source returned by List")
+		}
+	}
+	all := r.All()
+	if len(all) != 1 || all[0].Alias != "infoq" || all[0].Enabled || all[0].Origin != OriginOverride {
+		t.Errorf("All() = %+v", all)
+	}
+}
+
+func TestRegistryUserSources(t *testing.T) {
+	r := loadTestRegistry(t, `{"sources": [
+		{"alias": "mine", "name": "我的源", "url": "https://example.com/", "fetcher_type": "registry-test",
+		 "options": {"lang": "zh", "region": "cn"}},
+		{"alias": "mine", "options": {"region": "us"}},
+		{"alias": "blog", "name": "博客", "url": "https://blog.example.com/feed.xml", "fetcher_type": "rss"}
+	]}`)
+
+	if len(r.Warnings()) != 0 {
+		t.Fatalf("warnings = %q", r.Warnings())
+	}
+
+	mine, ok := r.Get("mine")
+	if !ok {
+		t.Fatal("user source missing")
+	}
+	if mine.Origin != OriginUser || !mine.Enabled {
+		t.Errorf("mine = %+v", mine)
+	}
+	// options 按参数名合并
+	if mine.Options["lang"] != "zh" || mine.Options["region"] != "us" {
+		t.Errorf("Options = %v, want lang=zh region=us", mine.Options)
+	}
+
+	var aliases []string
+	for _, source := range r.List() {
+		aliases = append(aliases, source.Alias)
+	}
+	if got := strings.Join(aliases, ","); got != "blog,infoq,mine" {
+		t.Errorf("List() aliases = %s, want sorted blog,infoq,mine", got)
+	}
+}
+
+func TestRegistrySkipsInvalidEntries(t *testing.T) {
+	r := loadTestRegistry(t, `{"sources": [
+		{"alias": "", "name": "无别名", "url": "https://example.com", "fetcher_type": "rss"},
+		{"alias": "has space", "name": "带空格", "url": "https://example.com", "fetcher_type": "rss"},
+		{"alias": "noname", "url": "https://example.com", "fetcher_type": "rss"},
+		{"alias": "ftp", "name": "FTP", "url": "ftp://example.com", "fetcher_type": "rss"},
+		{"alias": "unknown", "name": "未知类型", "url": "https://example.com", "fetcher_type": "nope"},
+		{"alias": "badopt", "name": "未声明参数", "url": "https://example.com", "fetcher_type": "registry-test", "options": {"x": "1"}},
+		{"alias": "nosel", "name": "缺少选择器", "url": "https://example.com", "fetcher_type": "selector"},
+		{"alias": "infoq", "url": "not a url"},
+		{"alias": "ok", "name": "有效", "url": "https://example.com/feed", "fetcher_type": "rss"}
+	]}`)
+
+	if got := len(r.Warnings()); got != 8 {
+		t.Errorf("got %d warnings, want 8: %q", got, r.Warnings())
+	}
+	for _, want := range []string{"别名无效", "缺少名称", "HTTP", "不支持的抓取器类型: nope", "options.x", "selectors", "infoq 的覆盖无效"} {
+		found := false
+		for _, warning := range r.Warnings() {
+			if strings.Contains(warning, want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no warning mentions %q: %q", want, r.Warnings())
+		}
+	}
+
+	// 有效条目和内置定义不受影响
+	if _, ok := r.Get("ok"); !ok {
+		t.Error("valid entry skipped")
+	}
+	if infoq, _ := r.Get("infoq"); infoq == nil || infoq.URL != "https://www.infoq.cn/hotlist" || infoq.Origin != OriginBuiltin {
+		t.Errorf("invalid override changed infoq: %+v", infoq)
+	}
+	if len(r.All()) != 2 {
+		t.Errorf("All() = %d sources, want 2", len(r.All()))
+	}
+}
+
+func TestRegistryBrokenFile(t *testing.T) {
+	r := loadTestRegistry(t, `{"sources": [`)
+
+	if len(r.Warnings()) != 1 || !strings.Contains(r.Warnings()[0], "格式错误") {
+		t.Errorf("warnings = %q", r.Warnings())
+	}
+	if _, ok := r.Get("infoq"); !ok {
+		t.Error("builtin sources lost when sources.json is broken")
+	}
+}
@@ -0,0 +1,311 @@
+package official
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"news4coder/internal/search"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html/charset"
+)
+
+// RSSFetcher RSS/Atom 订阅源抓取器，支持 RSS 2.0、RSS 1.0 (RDF) 与 Atom 1.0
+type RSSFetcher struct {
+	url    string
+	client *http.Client
+}
+
+func init() {
+	RegisterFetcher(FetcherType{
+		Name:        "rss",
+		Description: "读取 RSS/Atom 订阅源",
+		New: func(source *Source) (Fetcher, error) {
+			return NewRSSFetcher(source.URL), nil
+		},
+	})
+}
+
+// NewRSSFetcher 创建 RSS/Atom 抓取器实例
+func NewRSSFetcher(url string) *RSSFetcher {
+	return &RSSFetcher{
+		url: url,
+		client: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+	}
+}
+
+// maxFeedSize 订阅源文档的最大读取大小
+const maxFeedSize = 10 << 20
+
+// rss2Feed RSS 2.0 文档结构
+type rss2Feed struct {
+	Channel struct {
+		Items []feedItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+// rdfFeed RSS 1.0 (RDF) 文档结构，item 与 channel 同级
+type rdfFeed struct {
+	Items []feedItem `xml:"item"`
+}
+
+// feedItem RSS 2.0 / RSS 1.0 条目
+type feedItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	Description string `xml:"description"`
+	Encoded     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PubDate     string `xml:"pubDate"`
+	DCDate      string `xml:"http://purl.org/dc/elements/1.1/ date"`
+}
+
+// atomFeed Atom 1.0 文档结构
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomEntry Atom 条目
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	ID        string     `xml:"id"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+}
+
+// atomLink Atom 链接
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// Fetch 抓取并解析订阅源
+func (f *RSSFetcher) Fetch(ctx context.Context) ([]search.SearchResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", f.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/rdf+xml, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.5")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("网络请求失败: %w\n\n建议:\n1. 检查网络连接\n2. 直接访问: %s", err, f.url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d\n\n建议:\n直接访问: %s", resp.StatusCode, f.url)
+	}
+
+	// 多读 1 字节用于判断是否超过上限
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+	if len(data) > maxFeedSize {
+		return nil, fmt.Errorf("订阅源超过 %d MB，已放弃读取: %s", maxFeedSize>>20, f.url)
+	}
+
+	results, err := ParseFeed(data, f.url)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("订阅源暂无内容\n\n建议:\n访问原页面: %s", f.url)
+	}
+
+	return results, nil
+}
+
+// ParseFeed 解析 RSS 2.0、RSS 1.0 (RDF) 或 Atom 1.0 文档，相对链接基于 base（订阅源地址）补全
+func ParseFeed(data []byte, base string) ([]search.SearchResult, error) {
+	root, err := feedRootName(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []search.SearchResult
+	switch root {
+	case "rss":
+		var feed rss2Feed
+		if err := decodeFeed(data, &feed); err != nil {
+			return nil, err
+		}
+		items = convertFeedItems(feed.Channel.Items, base)
+	case "RDF":
+		var feed rdfFeed
+		if err := decodeFeed(data, &feed); err != nil {
+			return nil, err
+		}
+		items = convertFeedItems(feed.Items, base)
+	case "feed":
+		var feed atomFeed
+		if err := decodeFeed(data, &feed); err != nil {
+			return nil, err
+		}
+		items = convertAtomEntries(feed.Entries, base)
+	default:
+		return nil, fmt.Errorf("不支持的订阅源格式: <%s>", root)
+	}
+
+	// 编号并限制数量
+	var results []search.SearchResult
+	for _, item := range items {
+		if len(results) >= 10 {
+			break
+		}
+		item.Index = len(results) + 1
+		results = append(results, item)
+	}
+
+	return results, nil
+}
+
+// newFeedDecoder 创建支持非 UTF-8 编码的 XML 解码器
+func newFeedDecoder(data []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+	return decoder
+}
+
+// feedRootName 获取文档根元素名称
+func feedRootName(data []byte) (string, error) {
+	decoder := newFeedDecoder(data)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("订阅源解析失败: %w", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// decodeFeed 将文档解码到指定结构
+func decodeFeed(data []byte, v any) error {
+	if err := newFeedDecoder(data).Decode(v); err != nil {
+		return fmt.Errorf("订阅源解析失败: %w", err)
+	}
+	return nil
+}
+
+// convertFeedItems 转换 RSS 条目
+func convertFeedItems(items []feedItem, base string) []search.SearchResult {
+	var results []search.SearchResult
+	for _, item := range items {
+		link := strings.TrimSpace(item.Link)
+		if link == "" && strings.HasPrefix(strings.TrimSpace(item.GUID), "http") {
+			link = strings.TrimSpace(item.GUID)
+		}
+
+		summary := item.Description
+		if strings.TrimSpace(summary) == "" {
+			summary = item.Encoded
+		}
+
+		date := item.PubDate
+		if strings.TrimSpace(date) == "" {
+			date = item.DCDate
+		}
+
+		result := search.SearchResult{
+			Title:         cleanText(item.Title),
+			URL:           resolveLink(base, link),
+			Snippet:       summarize(summary),
+			PublishedDate: parseFeedDate(date),
+		}
+		if result.Title != "" && result.URL != "" {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// convertAtomEntries 转换 Atom 条目
+func convertAtomEntries(entries []atomEntry, base string) []search.SearchResult {
+	var results []search.SearchResult
+	for _, entry := range entries {
+		summary := entry.Summary
+		if strings.TrimSpace(summary) == "" {
+			summary = entry.Content
+		}
+
+		date := entry.Published
+		if strings.TrimSpace(date) == "" {
+			date = entry.Updated
+		}
+
+		result := search.SearchResult{
+			Title:         cleanText(entry.Title),
+			URL:           resolveLink(base, atomEntryLink(entry)),
+			Snippet:       summarize(summary),
+			PublishedDate: parseFeedDate(date),
+		}
+		if result.Title != "" && result.URL != "" {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// atomEntryLink 选取 Atom 条目的正文链接（优先 rel="alternate"）
+func atomEntryLink(entry atomEntry) string {
+	for _, link := range entry.Links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	if len(entry.Links) > 0 {
+		return strings.TrimSpace(entry.Links[0].Href)
+	}
+	if strings.HasPrefix(strings.TrimSpace(entry.ID), "http") {
+		return strings.TrimSpace(entry.ID)
+	}
+	return ""
+}
+
+// cleanText 合并多余空白
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// summarize 去除 HTML 标签并截断摘要
+func summarize(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return ""
+	}
+
+	text := s
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(s)); err == nil {
+		text = doc.Text()
+	}
+	text = cleanText(text)
+
+	runes := []rune(text)
+	if len(runes) > 200 {
+		return string(runes[:200]) + "..."
+	}
+	return text
+}
+
+// parseFeedDate 解析订阅源日期，无法识别时返回零值
+func parseFeedDate(s string) time.Time {
+	t, _ := search.ParseDate(s)
+	return t
+}
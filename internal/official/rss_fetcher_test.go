@@ -0,0 +1,143 @@
+package official
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFeed(t *testing.T) {
+	type want struct {
+		title   string
+		url     string
+		snippet string
+		date    string // RFC3339，空表示没有日期
+	}
+
+	tests := []struct {
+		file string
+		base string
+		want []want
+	}{
+		{
+			file: "rss2.xml",
+			base: "https://blog.example.com/feed.xml",
+			want: []want{
+				{"Go 1.23 发布", "https://blog.example.com/go1.23", "新版本带来了 range over func。", "2024-08-13T10:00:00+08:00"},
+				{"相对链接 与 dc:date", "https://blog.example.com/posts/relative", "只有 content:encoded 的摘要", "2024-08-01T08:30:00Z"},
+				{"只有 guid 的条目", "https://blog.example.com/guid-only", "", ""},
+			},
+		},
+		{
+			file: "rdf.xml",
+			base: "https://news.example.org/index.rdf",
+			want: []want{
+				{"First RDF item", "https://news.example.org/a", "Plain text description", "2024-07-30T12:00:00+09:00"},
+				{"Second RDF item", "https://news.example.org/b?ref=rss", "", ""},
+			},
+		},
+		{
+			file: "atom.xml",
+			base: "https://atom.example.net/feed.atom",
+			want: []want{
+				{"Alternate link wins", "https://atom.example.net/entries/1", "Summary text", "2024-06-01T09:00:00-07:00"},
+				{"Relative link and updated only", "https://atom.example.net/entries/2", "Content used as summary", "2024-06-03T00:00:00Z"},
+				{"Id as link", "https://atom.example.net/entries/3", "", ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results, err := ParseFeed(data, tt.base)
+			if err != nil {
+				t.Fatalf("ParseFeed: %v", err)
+			}
+			if len(results) != len(tt.want) {
+				t.Fatalf("got %d results, want %d: %+v", len(results), len(tt.want), results)
+			}
+
+			for i, w := range tt.want {
+				r := results[i]
+				if r.Index != i+1 {
+					t.Errorf("[%d] Index = %d, want %d", i, r.Index, i+1)
+				}
+				if r.Title != w.title {
+					t.Errorf("[%d] Title = %q, want %q", i, r.Title, w.title)
+				}
+				if r.URL != w.url {
+					t.Errorf("[%d] URL = %q, want %q", i, r.URL, w.url)
+				}
+				if r.Snippet != w.snippet {
+					t.Errorf("[%d] Snippet = %q, want %q", i, r.Snippet, w.snippet)
+				}
+				if w.date == "" {
+					if !r.PublishedDate.IsZero() {
+						t.Errorf("[%d] PublishedDate = %v, want zero", i, r.PublishedDate)
+					}
+					continue
+				}
+				wantDate, _ := time.Parse(time.RFC3339, w.date)
+				if !r.PublishedDate.Equal(wantDate) {
+					t.Errorf("[%d] PublishedDate = %v, want %v", i, r.PublishedDate, wantDate)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFeedLimit(t *testing.T) {
+	var b strings.Builder
+	b.WriteString(`<rss version="2.0"><channel>`)
+	for i := 0; i < 15; i++ {
+		b.WriteString(`<item><title>item</title><link>https://example.com/` + string(rune('a'+i)) + `</link></item>`)
+	}
+	b.WriteString(`</channel></rss>`)
+
+	results, err := ParseFeed([]byte(b.String()), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 10 {
+		t.Fatalf("got %d results, want 10", len(results))
+	}
+}
+
+func TestParseFeedErrors(t *testing.T) {
+	tests := map[string]string{
+		"html":    `<html><body>not a feed</body></html>`,
+		"invalid": `not xml at all`,
+		"empty":   ``,
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseFeed([]byte(input), ""); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestRSSFetcherSizeLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<rss version="2.0"><channel><description>`))
+		w.Write([]byte(strings.Repeat("x", maxFeedSize)))
+		w.Write([]byte(`</description></channel></rss>`))
+	}))
+	defer server.Close()
+
+	_, err := NewRSSFetcher(server.URL).Fetch(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "MB") {
+		t.Fatalf("expected size limit error, got %v", err)
+	}
+}
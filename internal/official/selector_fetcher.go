@@ -0,0 +1,220 @@
+package official
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"news4coder/internal/search"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+)
+
+// SelectorConfig 声明式 CSS 选择器配置，描述如何从列表页面提取文章
+//
+// 除 Item 外，各选择器都在列表项内部查找，留空时使用默认规则：
+// 标题为列表项文本；链接为列表项自身的 href，或列表项内第一个 <a>；
+// 摘要为空；发布时间从列表项内的 <time> 元素提取。
+type SelectorConfig struct {
+	Item    string `json:"item"`               // 列表项选择器（必填）
+	Title   string `json:"title,omitempty"`    // 标题选择器
+	Link    string `json:"link,omitempty"`     // 链接选择器，取 href 属性
+	Snippet string `json:"snippet,omitempty"`  // 摘要选择器
+	Date    string `json:"date,omitempty"`     // 发布时间选择器，优先取 datetime 属性，否则取文本
+	BaseURL string `json:"base_url,omitempty"` // 补全相对链接的基础地址（默认为页面地址）
+}
+
+// Validate 校验选择器配置
+func (c *SelectorConfig) Validate() error {
+	if strings.TrimSpace(c.Item) == "" {
+		return fmt.Errorf("缺少列表项选择器（item）")
+	}
+
+	fields := []struct{ name, selector string }{
+		{"item", c.Item},
+		{"title", c.Title},
+		{"link", c.Link},
+		{"snippet", c.Snippet},
+		{"date", c.Date},
+	}
+	for _, field := range fields {
+		if field.selector == "" {
+			continue
+		}
+		if _, err := cascadia.ParseGroup(field.selector); err != nil {
+			return fmt.Errorf("选择器 %s 无效: %w", field.name, err)
+		}
+	}
+
+	if c.BaseURL != "" {
+		if u, err := url.Parse(c.BaseURL); err != nil || !u.IsAbs() {
+			return fmt.Errorf("base_url 必须是完整的 URL: %s", c.BaseURL)
+		}
+	}
+	return nil
+}
+
+// SelectorFetcher 通用的 CSS 选择器抓取器，抓取规则由 SelectorConfig 描述
+type SelectorFetcher struct {
+	url    string
+	config SelectorConfig
+	client *http.Client
+}
+
+func init() {
+	RegisterFetcher(FetcherType{
+		Name:        "selector",
+		Description: "通用 CSS 选择器抓取器，抓取规则由 selectors 声明",
+		Schema: ConfigSchema{
+			Selectors: true,
+			Validate: func(source *Source) error {
+				if err := source.Selectors.Validate(); err != nil {
+					return fmt.Errorf("选择器配置无效: %w", err)
+				}
+				return nil
+			},
+		},
+		New: func(source *Source) (Fetcher, error) {
+			return NewSelectorFetcher(source.URL, *source.Selectors), nil
+		},
+	})
+}
+
+// NewSelectorFetcher 创建选择器抓取器实例
+func NewSelectorFetcher(url string, config SelectorConfig) *SelectorFetcher {
+	return &SelectorFetcher{
+		url:    url,
+		config: config,
+		client: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+	}
+}
+
+// Fetch 抓取页面并按选择器提取文章列表
+func (f *SelectorFetcher) Fetch(ctx context.Context) ([]search.SearchResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", f.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9,en;q=0.8")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("网络请求失败: %w\n\n建议:\n1. 检查网络连接\n2. 直接访问: %s", err, f.url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d\n\n建议:\n直接访问: %s", resp.StatusCode, f.url)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("HTML解析失败: %w", err)
+	}
+
+	base := f.config.BaseURL
+	if base == "" {
+		base = f.url
+	}
+
+	results := ParseWithSelectors(doc, f.config, base)
+	if len(results) == 0 {
+		return nil, fmt.Errorf("未找到内容，选择器 %q 没有匹配到文章，页面结构可能已变更\n\n建议:\n直接访问: %s", f.config.Item, f.url)
+	}
+	return results, nil
+}
+
+// ParseWithSelectors 按选择器配置从页面提取文章列表（最多 10 条），相对链接基于 base 补全
+func ParseWithSelectors(doc *goquery.Document, config SelectorConfig, base string) []search.SearchResult {
+	var results []search.SearchResult
+	seen := make(map[string]bool)
+
+	doc.Find(config.Item).EachWithBreak(func(i int, item *goquery.Selection) bool {
+		result := search.SearchResult{
+			Title:         cleanText(selectText(item, config.Title)),
+			URL:           resolveLink(base, selectLink(item, config.Link)),
+			PublishedDate: selectDate(item, config.Date),
+		}
+		if config.Snippet != "" {
+			result.Snippet = summarize(item.Find(config.Snippet).First().Text())
+		}
+
+		// 只添加有效的结果（至少有标题和URL）
+		if result.Title != "" && result.URL != "" && !seen[result.URL] {
+			seen[result.URL] = true
+			result.Index = len(results) + 1
+			results = append(results, result)
+		}
+		return len(results) < 10
+	})
+
+	return results
+}
+
+// selectText 返回列表项内第一个匹配元素的文本，选择器为空时返回列表项文本
+func selectText(item *goquery.Selection, selector string) string {
+	if selector == "" {
+		return item.Text()
+	}
+	return item.Find(selector).First().Text()
+}
+
+// selectLink 返回列表项的链接：选择器为空时取列表项自身或其中第一个 <a> 的 href
+func selectLink(item *goquery.Selection, selector string) string {
+	if selector != "" {
+		href, _ := item.Find(selector).First().Attr("href")
+		return href
+	}
+	if href, ok := item.Attr("href"); ok {
+		return href
+	}
+	href, _ := item.Find("a[href]").First().Attr("href")
+	return href
+}
+
+// selectDate 提取发布时间：优先使用选择器匹配元素的 datetime 属性或文本，再尝试 <time> 元素
+func selectDate(item *goquery.Selection, selector string) time.Time {
+	if selector != "" {
+		elem := item.Find(selector).First()
+		value, ok := elem.Attr("datetime")
+		if !ok {
+			value = elem.Text()
+		}
+		if t, ok := search.ParseDate(value); ok {
+			return t
+		}
+	}
+	t, _ := search.ExtractSelectionDate(item)
+	return t
+}
+
+// resolveLink 将页面中的链接补全为绝对地址，无法解析时返回空字符串
+func resolveLink(base, href string) string {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "javascript:") || strings.HasPrefix(href, "#") {
+		return ""
+	}
+
+	ref, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ""
+	}
+
+	resolved := baseURL.ResolveReference(ref)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return ""
+	}
+	return resolved.String()
+}
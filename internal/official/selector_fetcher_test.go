@@ -0,0 +1,193 @@
+package official
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// postSelectors 对应 testdata/selector_list.html 中的文章列表
+var postSelectors = SelectorConfig{
+	Item:    "article.post",
+	Title:   "h2",
+	Link:    "h2 a",
+	Snippet: "p.summary",
+	Date:    ".date",
+}
+
+func loadSelectorFixture(t *testing.T) *goquery.Document {
+	t.Helper()
+	file, err := os.Open(filepath.Join("testdata", "selector_list.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	doc, err := goquery.NewDocumentFromReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestParseWithSelectors(t *testing.T) {
+	doc := loadSelectorFixture(t)
+	results := ParseWithSelectors(doc, postSelectors, "https://blog.example.com/posts/index.html")
+
+	// 缺少标题或链接、无效链接和重复链接的列表项被跳过，结果最多 10 条
+	if len(results) != 10 {
+		t.Fatalf("got %d results, want 10: %+v", len(results), results)
+	}
+
+	want := []struct {
+		title string
+		url   string
+	}{
+		{"第一篇：Go 泛型实践", "https://blog.example.com/posts/first"},
+		{"第二篇：错误处理", "https://blog.example.com/posts/second.html"},
+		{"第三篇：外部链接", "https://other.example.org/third"},
+		{"第 4 篇", "https://blog.example.com/posts/4"},
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Index != i+1 || r.Title != w.title || r.URL != w.url {
+			t.Errorf("[%d] got (%d, %q, %q), want (%d, %q, %q)", i, r.Index, r.Title, r.URL, i+1, w.title, w.url)
+		}
+	}
+	if last := results[9]; last.Title != "第 10 篇" {
+		t.Errorf("last result = %q, want %q", last.Title, "第 10 篇")
+	}
+
+	// datetime 属性优先于元素文本（"3 天前"）
+	if want := time.Date(2024, 8, 12, 10, 0, 0, 0, time.FixedZone("CST", 8*3600)); !results[0].PublishedDate.Equal(want) {
+		t.Errorf("attribute date = %v, want %v", results[0].PublishedDate, want)
+	}
+	// 没有 datetime 属性时取文本
+	if y, m, d := results[1].PublishedDate.Date(); y != 2024 || m != time.August || d != 10 {
+		t.Errorf("text date = %v, want 2024-08-10", results[1].PublishedDate)
+	}
+	// 选择器未匹配时回退到列表项内的 <time> 元素
+	if y, m, d := results[2].PublishedDate.Date(); y != 2024 || m != time.August || d != 1 {
+		t.Errorf("fallback date = %v, want 2024-08-01", results[2].PublishedDate)
+	}
+	if !results[3].PublishedDate.IsZero() {
+		t.Errorf("missing date = %v, want zero", results[3].PublishedDate)
+	}
+
+	if results[0].Snippet != "泛型让容器类型的代码更简洁。" {
+		t.Errorf("snippet = %q", results[0].Snippet)
+	}
+	if results[1].Snippet != "" {
+		t.Errorf("missing snippet = %q, want empty", results[1].Snippet)
+	}
+}
+
+func TestParseWithSelectorsBaseURL(t *testing.T) {
+	doc := loadSelectorFixture(t)
+	results := ParseWithSelectors(doc, postSelectors, "https://mirror.example.net/archive/")
+
+	if len(results) < 2 {
+		t.Fatalf("got %d results", len(results))
+	}
+	if got, want := results[0].URL, "https://mirror.example.net/posts/first"; got != want {
+		t.Errorf("root-relative URL = %q, want %q", got, want)
+	}
+	if got, want := results[1].URL, "https://mirror.example.net/archive/second.html"; got != want {
+		t.Errorf("path-relative URL = %q, want %q", got, want)
+	}
+}
+
+func TestParseWithSelectorsDefaults(t *testing.T) {
+	doc := loadSelectorFixture(t)
+	base := "https://blog.example.com/"
+
+	// 列表项本身是链接：标题取列表项文本，链接取列表项的 href，锚点链接被跳过
+	results := ParseWithSelectors(doc, SelectorConfig{Item: "nav.links a.item"}, base)
+	if len(results) != 2 {
+		t.Fatalf("nav: got %d results, want 2: %+v", len(results), results)
+	}
+	if results[0].Title != "Go" || results[0].URL != "https://blog.example.com/tags/go" {
+		t.Errorf("nav: got (%q, %q)", results[0].Title, results[0].URL)
+	}
+
+	// 列表项内第一个 <a> 作为链接，没有链接的列表项被跳过
+	results = ParseWithSelectors(doc, SelectorConfig{Item: "ul.recent li"}, base)
+	if len(results) != 1 {
+		t.Fatalf("recent: got %d results, want 1: %+v", len(results), results)
+	}
+	if results[0].Title != "最近：更新日志" || results[0].URL != "https://blog.example.com/recent/1" {
+		t.Errorf("recent: got (%q, %q)", results[0].Title, results[0].URL)
+	}
+
+	// 列表项选择器没有匹配时返回空结果
+	if results := ParseWithSelectors(doc, SelectorConfig{Item: "div.missing"}, base); len(results) != 0 {
+		t.Errorf("missing item: got %d results, want 0", len(results))
+	}
+}
+
+func TestSelectorFetcherFetch(t *testing.T) {
+	page, err := os.ReadFile(filepath.Join("testdata", "selector_list.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/blog/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	}))
+	defer server.Close()
+
+	// 未设置 base_url 时，相对链接基于页面地址补全
+	results, err := NewSelectorFetcher(server.URL+"/blog/", postSelectors).Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := results[1].URL, server.URL+"/blog/second.html"; got != want {
+		t.Errorf("URL = %q, want %q", got, want)
+	}
+
+	config := postSelectors
+	config.Item = "div.missing"
+	_, err = NewSelectorFetcher(server.URL+"/blog/", config).Fetch(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "div.missing") {
+		t.Errorf("no match error = %v", err)
+	}
+
+	_, err = NewSelectorFetcher(server.URL+"/gone", postSelectors).Fetch(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("status error = %v", err)
+	}
+}
+
+func TestSelectorConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SelectorConfig
+		wantErr bool
+	}{
+		{"valid", postSelectors, false},
+		{"missing item", SelectorConfig{Title: "h2"}, true},
+		{"blank item", SelectorConfig{Item: "  "}, true},
+		{"invalid selector", SelectorConfig{Item: "article", Link: "a[href"}, true},
+		{"relative base_url", SelectorConfig{Item: "article", BaseURL: "/posts"}, true},
+		{"absolute base_url", SelectorConfig{Item: "article", BaseURL: "https://example.com/"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
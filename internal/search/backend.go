@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// 内置搜索后端名称
+const (
+	BackendDuckDuckGo = "duckduckgo"
+	BackendBing       = "bing"
+	BackendSearXNG    = "searxng"
+
+	// DefaultBackend 默认搜索后端
+	DefaultBackend = BackendDuckDuckGo
+)
+
+// Backend 搜索后端接口
+type Backend interface {
+	// Name 返回后端名称
+	Name() string
+	// Search 执行查询并返回结果列表，ctx 取消时中止进行中的请求
+	Search(ctx context.Context, query string) ([]SearchResult, error)
+	// SearchPageURL 返回可在浏览器中打开的查询地址，用于错误提示
+	SearchPageURL(query string) string
+}
+
+// BackendOptions 创建搜索后端时的可选参数
+type BackendOptions struct {
+	SearXNGURL string // SearXNG 实例地址，例如 https://searx.example.org
+}
+
+// BackendNames 返回所有内置搜索后端名称
+func BackendNames() []string {
+	return []string{BackendDuckDuckGo, BackendBing, BackendSearXNG}
+}
+
+// IsValidBackend 判断搜索后端名称是否受支持
+func IsValidBackend(name string) bool {
+	for _, n := range BackendNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NewBackend 根据名称创建搜索后端，名称为空时使用默认后端
+func NewBackend(name string, opts BackendOptions) (Backend, error) {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	switch strings.ToLower(name) {
+	case "", BackendDuckDuckGo:
+		return &DuckDuckGoBackend{client: client}, nil
+	case BackendBing:
+		return &BingBackend{client: client}, nil
+	case BackendSearXNG:
+		if opts.SearXNGURL == "" {
+			return nil, fmt.Errorf("使用 SearXNG 后端需要指定实例地址（--searxng-url 或环境变量 NEWS4CODER_SEARXNG_URL）")
+		}
+		return &SearXNGBackend{baseURL: strings.TrimRight(opts.SearXNGURL, "/"), client: client}, nil
+	default:
+		return nil, fmt.Errorf("不支持的搜索后端: %s（可选: %s）", name, strings.Join(BackendNames(), ", "))
+	}
+}
+
+// setBrowserHeaders 设置请求头模拟真实浏览器
+func setBrowserHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9,en;q=0.8")
+	req.Header.Set("Accept-Encoding", "identity")
+}
+
+// saveDebugHTML 设置 DEBUG_SEARCH=1 时将未解析出结果的页面保存到当前目录以便分析；
+// 提示输出到标准错误，避免混入机器可读输出
+func saveDebugHTML(doc *goquery.Document, name string) {
+	if os.Getenv("DEBUG_SEARCH") != "1" {
+		return
+	}
+
+	htmlContent, err := doc.Html()
+	if err == nil {
+		err = os.WriteFile(name, []byte(htmlContent), 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "保存调试页面 %s 失败: %v\n", name, err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "已保存HTML到 %s\n", name)
+}
@@ -0,0 +1,115 @@
+package search
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// BingBackend 必应 HTML 搜索后端
+type BingBackend struct {
+	client *http.Client
+}
+
+// Name 返回后端名称
+func (b *BingBackend) Name() string {
+	return BackendBing
+}
+
+// SearchPageURL 返回浏览器中的查询地址
+func (b *BingBackend) SearchPageURL(query string) string {
+	return fmt.Sprintf("https://www.bing.com/search?q=%s", url.QueryEscape(query))
+}
+
+// extractBingURL 从必应跳转链接中提取真实URL
+func extractBingURL(href string) string {
+	// 必应跳转链接格式: https://www.bing.com/ck/a?...&u=a1<base64url>&...
+	if !strings.Contains(href, "bing.com/ck/a") {
+		return href
+	}
+	parsed, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+	encoded := strings.TrimPrefix(parsed.Query().Get("u"), "a1")
+	if encoded == "" {
+		return href
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return href
+	}
+	return string(decoded)
+}
+
+// Search 使用必应执行查询
+func (b *BingBackend) Search(ctx context.Context, query string) ([]SearchResult, error) {
+	searchURL := fmt.Sprintf("https://www.bing.com/search?q=%s&count=20", url.QueryEscape(query))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	setBrowserHeaders(req)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("网络请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("搜索请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("HTML解析失败: %w", err)
+	}
+
+	results := []SearchResult{}
+
+	// 必应自然搜索结果选择器
+	doc.Find("#b_results > li.b_algo").Each(func(i int, s *goquery.Selection) {
+		result := SearchResult{}
+
+		titleElem := s.Find("h2 a").First()
+		if titleElem.Length() > 0 {
+			result.Title = strings.TrimSpace(titleElem.Text())
+			if href, exists := titleElem.Attr("href"); exists && strings.HasPrefix(href, "http") {
+				result.URL = extractBingURL(href)
+			}
+		}
+
+		snippetElem := s.Find(".b_caption p, .b_lineclamp2, .b_lineclamp3, .b_lineclamp4").First()
+		if snippetElem.Length() > 0 {
+			result.Snippet = strings.TrimSpace(snippetElem.Text())
+			if t, rest, ok := ExtractSnippetDate(result.Snippet); ok {
+				result.PublishedDate = t
+				result.Snippet = rest
+			}
+		}
+
+		// 部分结果在摘要中单独标注日期
+		if result.PublishedDate.IsZero() {
+			if t, ok := ParseDate(strings.Trim(s.Find(".news_dt").First().Text(), " ·")); ok {
+				result.PublishedDate = t
+			}
+		}
+
+		if result.Title != "" && result.URL != "" {
+			results = append(results, result)
+		}
+	})
+
+	if len(results) == 0 {
+		saveDebugHTML(doc, "debug_bing.html")
+	}
+
+	return results, nil
+}
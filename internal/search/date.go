@@ -0,0 +1,290 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// dateLayouts 常见的绝对日期格式，按优先级排列
+var dateLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC822Z,
+	time.RFC822,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 02 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04",
+	"2006/1/2",
+	"2006.01.02",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+	"2006年1月2日 15:04",
+	"2006年1月2日",
+}
+
+var (
+	// relativeZhPattern 中文相对时间，例如 "3小时前"、"5 分钟前"
+	relativeZhPattern = regexp.MustCompile(`^(\d+)\s*(秒|秒钟|分钟|小时|个小时|天|周|星期|个月|月|年)前$`)
+	// relativeEnPattern 英文相对时间，例如 "3 hours ago"
+	relativeEnPattern = regexp.MustCompile(`^(?i)(\d+)\s*(second|sec|minute|min|hour|hr|day|week|month|year)s?\s+ago$`)
+	// dayWordPattern 中文日期词，可带时刻，例如 "昨天 12:30"
+	dayWordPattern = regexp.MustCompile(`^(刚刚|今天|昨天|前天)(?:\s*(\d{1,2}):(\d{2}))?$`)
+	// monthDayZhPattern 省略年份的中文日期，例如 "3月5日"
+	monthDayZhPattern = regexp.MustCompile(`^(\d{1,2})月(\d{1,2})日$`)
+	// snippetSeparators 搜索摘要中日期前缀与正文之间的分隔符
+	snippetSeparators = []string{" · ", " — ", " - ", " ... ", "..."}
+)
+
+// ParseDate 解析绝对日期或相对日期（"3小时前"、"昨天"、"2 days ago" 等）
+func ParseDate(s string) (time.Time, bool) {
+	return parseDateAt(s, time.Now())
+}
+
+// parseDateAt 以 now 为基准解析日期
+func parseDateAt(s string, now time.Time) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, true
+		}
+	}
+
+	if m := relativeZhPattern.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return shiftTime(now, n, m[2]), true
+	}
+
+	if m := relativeEnPattern.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return shiftTime(now, n, strings.ToLower(m[2])), true
+	}
+
+	if m := dayWordPattern.FindStringSubmatch(s); m != nil {
+		if m[1] == "刚刚" {
+			return now, true
+		}
+		days := map[string]int{"今天": 0, "昨天": 1, "前天": 2}[m[1]]
+		y, mo, d := now.AddDate(0, 0, -days).Date()
+		hour, minute := 0, 0
+		if m[2] != "" {
+			hour, _ = strconv.Atoi(m[2])
+			minute, _ = strconv.Atoi(m[3])
+		}
+		return time.Date(y, mo, d, hour, minute, 0, 0, now.Location()), true
+	}
+
+	if m := monthDayZhPattern.FindStringSubmatch(s); m != nil {
+		month, _ := strconv.Atoi(m[1])
+		day, _ := strconv.Atoi(m[2])
+		t := time.Date(now.Year(), time.Month(month), day, 0, 0, 0, 0, now.Location())
+		// 未来日期视为去年
+		if t.After(now) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t, true
+	}
+
+	return time.Time{}, false
+}
+
+// shiftTime 将 now 向前推移 n 个单位
+func shiftTime(now time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "秒", "秒钟", "second", "sec":
+		return now.Add(-time.Duration(n) * time.Second)
+	case "分钟", "minute", "min":
+		return now.Add(-time.Duration(n) * time.Minute)
+	case "小时", "个小时", "hour", "hr":
+		return now.Add(-time.Duration(n) * time.Hour)
+	case "天", "day":
+		return now.AddDate(0, 0, -n)
+	case "周", "星期", "week":
+		return now.AddDate(0, 0, -7*n)
+	case "个月", "月", "month":
+		return now.AddDate(0, -n, 0)
+	default:
+		return now.AddDate(-n, 0, 0)
+	}
+}
+
+// ExtractSnippetDate 提取搜索摘要开头的日期前缀（例如 "Dec 5, 2023 · ..."、"3天前 · ..."），
+// 返回日期和去除前缀后的摘要
+func ExtractSnippetDate(snippet string) (time.Time, string, bool) {
+	return extractSnippetDateAt(snippet, time.Now())
+}
+
+// extractSnippetDateAt 以 now 为基准提取摘要开头的日期前缀
+func extractSnippetDateAt(snippet string, now time.Time) (time.Time, string, bool) {
+	for _, sep := range snippetSeparators {
+		idx := strings.Index(snippet, sep)
+		// 日期前缀不会太长
+		if idx <= 0 || idx > 40 {
+			continue
+		}
+		if t, ok := parseDateAt(snippet[:idx], now); ok {
+			return t, strings.TrimSpace(snippet[idx+len(sep):]), true
+		}
+	}
+	return time.Time{}, snippet, false
+}
+
+// ExtractPageDate 从文章页面中提取发布时间，依次检查
+// article:published_time 等 meta 标签、JSON-LD datePublished 和 <time datetime>
+func ExtractPageDate(doc *goquery.Document) (time.Time, bool) {
+	metaSelectors := []string{
+		`meta[property="article:published_time"]`,
+		`meta[property="og:published_time"]`,
+		`meta[name="article:published_time"]`,
+		`meta[itemprop="datePublished"]`,
+		`meta[name="pubdate"]`,
+		`meta[name="publishdate"]`,
+		`meta[name="date"]`,
+	}
+	for _, selector := range metaSelectors {
+		if content, ok := doc.Find(selector).First().Attr("content"); ok {
+			if t, ok := ParseDate(content); ok {
+				return t, true
+			}
+		}
+	}
+
+	var found time.Time
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		var data any
+		if err := json.Unmarshal([]byte(s.Text()), &data); err != nil {
+			return true
+		}
+		if value, ok := findJSONField(data, "datePublished"); ok {
+			if t, ok := ParseDate(value); ok {
+				found = t
+				return false
+			}
+		}
+		return true
+	})
+	if !found.IsZero() {
+		return found, true
+	}
+
+	return ExtractSelectionDate(doc.Selection)
+}
+
+// ExtractSelectionDate 从页面片段（如列表项）中提取 <time> 元素表示的日期
+func ExtractSelectionDate(s *goquery.Selection) (time.Time, bool) {
+	var found time.Time
+	s.Find("time").EachWithBreak(func(i int, timeElem *goquery.Selection) bool {
+		value, ok := timeElem.Attr("datetime")
+		if !ok {
+			value = timeElem.Text()
+		}
+		if t, ok := ParseDate(value); ok {
+			found = t
+			return false
+		}
+		return true
+	})
+	return found, !found.IsZero()
+}
+
+// findJSONField 在 JSON-LD 数据中递归查找字符串字段
+func findJSONField(data any, key string) (string, bool) {
+	switch v := data.(type) {
+	case map[string]any:
+		if value, ok := v[key].(string); ok {
+			return value, true
+		}
+		for _, child := range v {
+			if value, ok := findJSONField(child, key); ok {
+				return value, true
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if value, ok := findJSONField(child, key); ok {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// FillPageDates 为缺少发布时间的结果抓取原文页面并提取发布时间
+func FillPageDates(ctx context.Context, results []SearchResult) {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 4)
+
+	for i := range results {
+		if !results[i].PublishedDate.IsZero() {
+			continue
+		}
+		wg.Add(1)
+		go func(r *SearchResult) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			if t, ok := fetchPageDate(ctx, client, r.URL); ok {
+				r.PublishedDate = t
+			}
+		}(&results[i])
+	}
+
+	wg.Wait()
+}
+
+// fetchPageDate 抓取页面并提取发布时间
+func fetchPageDate(ctx context.Context, client *http.Client, pageURL string) (time.Time, bool) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return time.Time{}, false
+	}
+	setBrowserHeaders(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, false
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return ExtractPageDate(doc)
+}
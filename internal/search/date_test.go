@@ -0,0 +1,176 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// testNow 测试使用的固定基准时间（东八区）
+var testNow = time.Date(2024, 8, 15, 14, 30, 0, 0, time.FixedZone("CST", 8*3600))
+
+func TestParseDateAt(t *testing.T) {
+	cst := testNow.Location()
+	tests := []struct {
+		input string
+		want  time.Time
+		ok    bool
+	}{
+		// 带时区的绝对时间保留原时区
+		{"2024-08-13T10:00:00Z", time.Date(2024, 8, 13, 10, 0, 0, 0, time.UTC), true},
+		{"2024-08-13T10:00:00+09:00", time.Date(2024, 8, 13, 1, 0, 0, 0, time.UTC), true},
+		{"Tue, 13 Aug 2024 10:00:00 +0800", time.Date(2024, 8, 13, 2, 0, 0, 0, time.UTC), true},
+		{"Tue, 13 Aug 2024 10:00:00 GMT", time.Date(2024, 8, 13, 10, 0, 0, 0, time.UTC), true},
+		{"2024-08-13T10:00:00+0800", time.Date(2024, 8, 13, 2, 0, 0, 0, time.UTC), true},
+
+		// 不带时区的时间按 now 所在时区解析
+		{"2024-08-13 10:00", time.Date(2024, 8, 13, 10, 0, 0, 0, cst), true},
+		{"2024-08-13T10:00:00", time.Date(2024, 8, 13, 10, 0, 0, 0, cst), true},
+		{"2024-08-13", time.Date(2024, 8, 13, 0, 0, 0, 0, cst), true},
+		{"2024/08/13 09:05", time.Date(2024, 8, 13, 9, 5, 0, 0, cst), true},
+		{"2024/8/3", time.Date(2024, 8, 3, 0, 0, 0, 0, cst), true},
+		{"2024.08.13", time.Date(2024, 8, 13, 0, 0, 0, 0, cst), true},
+		{"Aug 13, 2024", time.Date(2024, 8, 13, 0, 0, 0, 0, cst), true},
+		{"August 13, 2024", time.Date(2024, 8, 13, 0, 0, 0, 0, cst), true},
+		{"13 Aug 2024", time.Date(2024, 8, 13, 0, 0, 0, 0, cst), true},
+		{"2024年8月13日", time.Date(2024, 8, 13, 0, 0, 0, 0, cst), true},
+		{"2024年8月13日 09:30", time.Date(2024, 8, 13, 9, 30, 0, 0, cst), true},
+		{"  2024-08-13  ", time.Date(2024, 8, 13, 0, 0, 0, 0, cst), true},
+
+		// 中文相对时间
+		{"刚刚", testNow, true},
+		{"30秒前", testNow.Add(-30 * time.Second), true},
+		{"5 分钟前", testNow.Add(-5 * time.Minute), true},
+		{"3小时前", testNow.Add(-3 * time.Hour), true},
+		{"2个小时前", testNow.Add(-2 * time.Hour), true},
+		{"3天前", time.Date(2024, 8, 12, 14, 30, 0, 0, cst), true},
+		{"1周前", time.Date(2024, 8, 8, 14, 30, 0, 0, cst), true},
+		{"2个月前", time.Date(2024, 6, 15, 14, 30, 0, 0, cst), true},
+		{"1年前", time.Date(2023, 8, 15, 14, 30, 0, 0, cst), true},
+		{"今天", time.Date(2024, 8, 15, 0, 0, 0, 0, cst), true},
+		{"昨天 12:30", time.Date(2024, 8, 14, 12, 30, 0, 0, cst), true},
+		{"前天", time.Date(2024, 8, 13, 0, 0, 0, 0, cst), true},
+		{"3月5日", time.Date(2024, 3, 5, 0, 0, 0, 0, cst), true},
+		// 省略年份的未来日期视为去年
+		{"12月25日", time.Date(2023, 12, 25, 0, 0, 0, 0, cst), true},
+
+		// 英文相对时间
+		{"3 hours ago", testNow.Add(-3 * time.Hour), true},
+		{"1 day ago", time.Date(2024, 8, 14, 14, 30, 0, 0, cst), true},
+		{"2 Weeks ago", time.Date(2024, 8, 1, 14, 30, 0, 0, cst), true},
+		{"10 mins ago", testNow.Add(-10 * time.Minute), true},
+
+		// 无法识别或容易误判的输入
+		{"", time.Time{}, false},
+		{"Go 1.23", time.Time{}, false},
+		{"1.23", time.Time{}, false},
+		{"v1.2.3", time.Time{}, false},
+		{"3.14.15", time.Time{}, false},
+		{"20240813", time.Time{}, false},
+		{"123456789", time.Time{}, false},
+		{"#4521", time.Time{}, false},
+		{"3天", time.Time{}, false},
+		{"hours ago", time.Time{}, false},
+		{"2024-13-45", time.Time{}, false},
+		{"明天", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := parseDateAt(tt.input, testNow)
+			if ok != tt.ok {
+				t.Fatalf("parseDateAt(%q) ok = %v, want %v (got %v)", tt.input, ok, tt.ok, got)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseDateAt(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractSnippetDateAt(t *testing.T) {
+	cst := testNow.Location()
+	tests := []struct {
+		snippet string
+		want    time.Time
+		rest    string
+		ok      bool
+	}{
+		{"Aug 13, 2024 · Go 1.23 正式发布", time.Date(2024, 8, 13, 0, 0, 0, 0, cst), "Go 1.23 正式发布", true},
+		{"3天前 · 微服务架构实践", time.Date(2024, 8, 12, 14, 30, 0, 0, cst), "微服务架构实践", true},
+		{"2024-08-01 — Release notes", time.Date(2024, 8, 1, 0, 0, 0, 0, cst), "Release notes", true},
+		{"5 hours ago ... Kubernetes 1.31", testNow.Add(-5 * time.Hour), "Kubernetes 1.31", true},
+		{"2024年8月1日 - 版本发布", time.Date(2024, 8, 1, 0, 0, 0, 0, cst), "版本发布", true},
+
+		// 前缀不是日期时摘要保持不变
+		{"Go 1.23 · 新特性", time.Time{}, "Go 1.23 · 新特性", false},
+		{"v1.2.3 - changelog", time.Time{}, "v1.2.3 - changelog", false},
+		{"Issue 12345 - crash on start", time.Time{}, "Issue 12345 - crash on start", false},
+		{"没有分隔符的摘要 2024-08-01", time.Time{}, "没有分隔符的摘要 2024-08-01", false},
+		// 分隔符出现得太靠后，不视为日期前缀
+		{"这是一段很长很长的正文内容，里面碰巧出现了分隔符号并且后面还有内容 - 2024-08-01", time.Time{}, "这是一段很长很长的正文内容，里面碰巧出现了分隔符号并且后面还有内容 - 2024-08-01", false},
+		{"", time.Time{}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.snippet, func(t *testing.T) {
+			got, rest, ok := extractSnippetDateAt(tt.snippet, testNow)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v (got %v)", ok, tt.ok, got)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("date = %v, want %v", got, tt.want)
+			}
+			if rest != tt.rest {
+				t.Errorf("rest = %q, want %q", rest, tt.rest)
+			}
+		})
+	}
+}
+
+func TestExtractPageDate(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want time.Time
+		ok   bool
+	}{
+		{
+			name: "meta",
+			html: `<head><meta property="article:published_time" content="2024-08-13T10:00:00+08:00"></head>`,
+			want: time.Date(2024, 8, 13, 2, 0, 0, 0, time.UTC),
+			ok:   true,
+		},
+		{
+			name: "json-ld",
+			html: `<script type="application/ld+json">{"@graph":[{"@type":"Article","datePublished":"2024-07-01T00:00:00Z"}]}</script>`,
+			want: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			ok:   true,
+		},
+		{
+			name: "time",
+			html: `<article><time datetime="2024-06-01T12:00:00Z">June 1</time></article>`,
+			want: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+			ok:   true,
+		},
+		{
+			name: "none",
+			html: `<p>Version 1.23 released</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := ExtractPageDate(doc)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("ExtractPageDate = %v, %v; want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
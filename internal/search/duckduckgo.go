@@ -0,0 +1,121 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// DuckDuckGoBackend DuckDuckGo HTML 版本搜索后端
+type DuckDuckGoBackend struct {
+	client *http.Client
+}
+
+// Name 返回后端名称
+func (b *DuckDuckGoBackend) Name() string {
+	return BackendDuckDuckGo
+}
+
+// SearchPageURL 返回浏览器中的查询地址
+func (b *DuckDuckGoBackend) SearchPageURL(query string) string {
+	return fmt.Sprintf("https://duckduckgo.com/?q=%s", url.QueryEscape(query))
+}
+
+// extractRealURL 从DuckDuckGo重定向链接中提取真实URL
+func extractRealURL(ddgURL string) string {
+	// DuckDuckGo链接格式: https://duckduckgo.com/l/?uddg=<encoded_url>&rut=...
+	if strings.Contains(ddgURL, "duckduckgo.com/l/") {
+		parsed, err := url.Parse(ddgURL)
+		if err == nil {
+			uddg := parsed.Query().Get("uddg")
+			if uddg != "" {
+				return uddg
+			}
+		}
+	}
+	return ddgURL
+}
+
+// Search 使用DuckDuckGo HTML版本执行查询
+func (b *DuckDuckGoBackend) Search(ctx context.Context, query string) ([]SearchResult, error) {
+	searchURL := fmt.Sprintf("https://html.duckduckgo.com/html/?q=%s", url.QueryEscape(query))
+
+	// 发送HTTP请求
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	setBrowserHeaders(req)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("网络请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("搜索请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	// 解析HTML
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("HTML解析失败: %w", err)
+	}
+
+	// 提取搜索结果
+	results := []SearchResult{}
+
+	// DuckDuckGo HTML版本的搜索结果选择器
+	doc.Find(".result").Each(func(i int, s *goquery.Selection) {
+		result := SearchResult{}
+
+		// 提取标题和链接
+		titleElem := s.Find(".result__a")
+		if titleElem.Length() > 0 {
+			result.Title = strings.TrimSpace(titleElem.Text())
+			if href, exists := titleElem.Attr("href"); exists {
+				// DuckDuckGo 会返回绝对URL
+				if strings.HasPrefix(href, "http") {
+					result.URL = extractRealURL(href)
+				} else if strings.HasPrefix(href, "//") {
+					result.URL = extractRealURL("https:" + href)
+				}
+			}
+		}
+
+		// 提取摘要，并识别摘要开头的日期前缀
+		snippetElem := s.Find(".result__snippet")
+		if snippetElem.Length() > 0 {
+			result.Snippet = strings.TrimSpace(snippetElem.Text())
+			if t, rest, ok := ExtractSnippetDate(result.Snippet); ok {
+				result.PublishedDate = t
+				result.Snippet = rest
+			}
+		}
+
+		// 结果链接行末尾可能附带收录时间
+		if result.PublishedDate.IsZero() {
+			s.Find(".result__extras__url span").Each(func(i int, span *goquery.Selection) {
+				if t, ok := ParseDate(span.Text()); ok {
+					result.PublishedDate = t
+				}
+			})
+		}
+
+		// 只添加有效的结果（至少有标题和URL）
+		if result.Title != "" && result.URL != "" {
+			results = append(results, result)
+		}
+	})
+
+	if len(results) == 0 {
+		saveDebugHTML(doc, "debug_ddg.html")
+	}
+
+	return results, nil
+}
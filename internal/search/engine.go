@@ -1,148 +1,120 @@
 package search
 
 import (
+	"context"
 	"fmt"
-	"net/http"
 	"net/url"
-	"os"
 	"strings"
-	"time"
-
-	"github.com/PuerkitoBio/goquery"
 )
 
-// Engine 搜索引擎接口
+// Engine 站内搜索引擎，将站点地址转换为 site: 查询并交由搜索后端执行
 type Engine struct {
-	client *http.Client
+	backend Backend
 }
 
-// NewEngine 创建新的搜索引擎实例
+// NewEngine 创建使用默认搜索后端的搜索引擎实例
 func NewEngine() *Engine {
-	return &Engine{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-	}
+	backend, _ := NewBackend(DefaultBackend, BackendOptions{})
+	return NewEngineWithBackend(backend)
 }
 
-// extractDomain 从URL中提取域名
-func extractDomain(urlStr string) (string, error) {
-	parsedURL, err := url.Parse(urlStr)
-	if err != nil {
-		return "", err
-	}
+// NewEngineWithBackend 创建使用指定搜索后端的搜索引擎实例
+func NewEngineWithBackend(backend Backend) *Engine {
+	return &Engine{backend: backend}
+}
 
-	// 返回主机名（例如：www.infoq.cn 或 infoq.cn）
-	host := parsedURL.Host
-	if host == "" {
-		return "", fmt.Errorf("无法从URL提取域名")
-	}
+// Backend 返回当前使用的搜索后端
+func (e *Engine) Backend() Backend {
+	return e.backend
+}
 
-	return host, nil
+// siteScope 站内搜索范围：主机名加可选的路径前缀
+type siteScope struct {
+	host string // 主机名（例如：go.dev）
+	path string // 路径前缀（例如：/blog），为空表示整站
 }
 
-// extractRealURL 从DuckDuckGo重定向链接中提取真实URL
-func extractRealURL(ddgURL string) string {
-	// DuckDuckGo链接格式: https://duckduckgo.com/l/?uddg=<encoded_url>&rut=...
-	if strings.Contains(ddgURL, "duckduckgo.com/l/") {
-		parsed, err := url.Parse(ddgURL)
-		if err == nil {
-			uddg := parsed.Query().Get("uddg")
-			if uddg != "" {
-				return uddg
-			}
-		}
-	}
-	return ddgURL
+// query 返回 site: 查询语句（例如：site:go.dev/blog）
+func (s siteScope) query() string {
+	return fmt.Sprintf("site:%s%s", s.host, s.path)
 }
 
-// Search 使用DuckDuckGo搜索指定网站的最新内容
-func (e *Engine) Search(siteURL string) ([]SearchResult, error) {
-	// 提取域名
-	domain, err := extractDomain(siteURL)
-	if err != nil {
-		return nil, fmt.Errorf("URL解析失败: %w", err)
+// contains 判断结果链接是否位于搜索范围内
+func (s siteScope) contains(resultURL string) bool {
+	// 整站搜索不做过滤，保留搜索引擎返回的子域名结果
+	if s.path == "" {
+		return true
 	}
 
-	// 构造DuckDuckGo搜索URL - 使用 site: 语法进行站内搜索
-	query := fmt.Sprintf("site:%s", domain)
-	searchURL := fmt.Sprintf("https://html.duckduckgo.com/html/?q=%s", url.QueryEscape(query))
-
-	// 发送HTTP请求
-	req, err := http.NewRequest("GET", searchURL, nil)
+	parsed, err := url.Parse(resultURL)
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
+		return false
+	}
+
+	if normalizeHost(parsed.Host) != normalizeHost(s.host) {
+		return false
 	}
 
-	// 设置请求头模拟真实浏览器
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9,en;q=0.8")
-	req.Header.Set("Accept-Encoding", "identity")
+	p := strings.TrimSuffix(parsed.Path, "/")
+	return p == s.path || strings.HasPrefix(p, s.path+"/")
+}
+
+// normalizeHost 统一主机名大小写并去除 www. 前缀
+func normalizeHost(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
 
-	resp, err := e.client.Do(req)
+// extractSiteScope 从URL中提取域名和路径前缀
+func extractSiteScope(urlStr string) (siteScope, error) {
+	parsedURL, err := url.Parse(urlStr)
 	if err != nil {
-		return nil, fmt.Errorf("网络请求失败: %w", err)
+		return siteScope{}, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("搜索请求失败，状态码: %d", resp.StatusCode)
+	// 主机名（例如：www.infoq.cn 或 infoq.cn）
+	host := parsedURL.Host
+	if host == "" {
+		return siteScope{}, fmt.Errorf("无法从URL提取域名")
 	}
 
-	// 解析HTML
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	// 路径前缀（例如：/blog），去除末尾斜杠
+	return siteScope{
+		host: host,
+		path: strings.TrimSuffix(parsedURL.Path, "/"),
+	}, nil
+}
+
+// Search 搜索指定网站的最新内容
+func (e *Engine) Search(ctx context.Context, siteURL string) ([]SearchResult, error) {
+	// 提取搜索范围
+	scope, err := extractSiteScope(siteURL)
 	if err != nil {
-		return nil, fmt.Errorf("HTML解析失败: %w", err)
+		return nil, fmt.Errorf("URL解析失败: %w", err)
 	}
 
-	// 提取搜索结果
-	results := []SearchResult{}
-	index := 1
+	// 使用 site: 语法进行站内搜索，保留路径前缀（例如：site:go.dev/blog）
+	query := scope.query()
 
-	// DuckDuckGo HTML版本的搜索结果选择器
-	doc.Find(".result").Each(func(i int, s *goquery.Selection) {
-		if index > 10 {
-			return
-		}
-
-		result := SearchResult{Index: index}
-
-		// 提取标题和链接
-		titleElem := s.Find(".result__a")
-		if titleElem.Length() > 0 {
-			result.Title = strings.TrimSpace(titleElem.Text())
-			if href, exists := titleElem.Attr("href"); exists {
-				// DuckDuckGo 会返回绝对URL
-				if strings.HasPrefix(href, "http") {
-					result.URL = extractRealURL(href)
-				} else if strings.HasPrefix(href, "//") {
-					result.URL = extractRealURL("https:" + href)
-				}
-			}
-		}
+	found, err := e.backend.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
 
-		// 提取摘要
-		snippetElem := s.Find(".result__snippet")
-		if snippetElem.Length() > 0 {
-			result.Snippet = strings.TrimSpace(snippetElem.Text())
+	// 过滤范围外的结果，编号并限制数量
+	var results []SearchResult
+	for _, result := range found {
+		if len(results) >= 10 {
+			break
 		}
-
-		// 只添加有效的结果（至少有标题和URL）
-		if result.Title != "" && result.URL != "" {
-			results = append(results, result)
-			index++
+		if !scope.contains(result.URL) {
+			continue
 		}
-	})
+		result.Index = len(results) + 1
+		results = append(results, result)
+	}
 
 	if len(results) == 0 {
-		// 调试：保存HTML到文件以便分析
-		if os.Getenv("DEBUG_SEARCH") == "1" {
-			htmlContent, _ := doc.Html()
-			os.WriteFile("debug_ddg.html", []byte(htmlContent), 0644)
-			fmt.Println("已保存HTML到 debug_ddg.html")
-		}
-		return nil, fmt.Errorf("未找到搜索结果。\n\n解决方法:\n1. 使用 --demo 参数查看演示效果\n2. 在浏览器中直接访问: https://duckduckgo.com/?q=%s", url.QueryEscape(query))
+		return nil, fmt.Errorf("未找到搜索结果。\n\n解决方法:\n1. 使用 --demo 参数查看演示效果\n2. 使用 --backend 切换搜索后端\n3. 在浏览器中直接访问: %s", e.backend.SearchPageURL(query))
 	}
 
 	return results, nil
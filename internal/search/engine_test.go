@@ -0,0 +1,72 @@
+package search
+
+import "testing"
+
+func TestExtractSiteScope(t *testing.T) {
+	tests := []struct {
+		url   string
+		host  string
+		path  string
+		query string
+	}{
+		{"https://go.dev", "go.dev", "", "site:go.dev"},
+		{"https://go.dev/", "go.dev", "", "site:go.dev"},
+		{"https://go.dev/blog", "go.dev", "/blog", "site:go.dev/blog"},
+		{"https://go.dev/blog/", "go.dev", "/blog", "site:go.dev/blog"},
+		{"https://www.infoq.cn/topic/go", "www.infoq.cn", "/topic/go", "site:www.infoq.cn/topic/go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			scope, err := extractSiteScope(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if scope.host != tt.host || scope.path != tt.path {
+				t.Errorf("scope = (%q, %q), want (%q, %q)", scope.host, scope.path, tt.host, tt.path)
+			}
+			if got := scope.query(); got != tt.query {
+				t.Errorf("query() = %q, want %q", got, tt.query)
+			}
+		})
+	}
+
+	if _, err := extractSiteScope("/blog"); err == nil {
+		t.Error("extractSiteScope accepted a URL without host")
+	}
+}
+
+func TestSiteScopeContains(t *testing.T) {
+	blog := siteScope{host: "go.dev", path: "/blog"}
+	wwwBlog := siteScope{host: "www.example.com", path: "/blog"}
+	site := siteScope{host: "go.dev"}
+
+	tests := []struct {
+		name   string
+		scope  siteScope
+		result string
+		want   bool
+	}{
+		{"prefix itself", blog, "https://go.dev/blog", true},
+		{"prefix with trailing slash", blog, "https://go.dev/blog/", true},
+		{"article under prefix", blog, "https://go.dev/blog/go1.23", true},
+		{"query and fragment", blog, "https://go.dev/blog/go1.23?x=1#top", true},
+		{"sibling path sharing prefix", blog, "https://go.dev/blogger/post", false},
+		{"parent path", blog, "https://go.dev/", false},
+		{"other section", blog, "https://go.dev/doc/blog", false},
+		{"other host", blog, "https://example.com/blog/post", false},
+		{"subdomain", blog, "https://pkg.go.dev/blog/post", false},
+		{"www added to result", blog, "https://www.go.dev/blog/post", true},
+		{"www removed from result", wwwBlog, "https://example.com/blog/post", true},
+		{"host case", wwwBlog, "https://WWW.Example.com/blog/post", true},
+		{"invalid url", blog, "://bad", false},
+		{"whole site keeps other paths", site, "https://go.dev/doc/", true},
+		{"whole site keeps subdomains", site, "https://pkg.go.dev/fmt", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.contains(tt.result); got != tt.want {
+				t.Errorf("%+v.contains(%q) = %v, want %v", tt.scope, tt.result, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,10 +1,69 @@
 package search
 
+import "time"
+
 // SearchResult 表示单条搜索结果
 type SearchResult struct {
-	Index         int    `json:"index"`          // 结果序号（1-10）
-	Title         string `json:"title"`          // 文章标题
-	URL           string `json:"url"`            // 文章链接
-	Snippet       string `json:"snippet"`        // 内容摘要
-	PublishedDate string `json:"published_date"` // 发布时间（如果可提取）
+	Index         int       `json:"index"`                   // 结果序号（1-10）
+	Title         string    `json:"title"`                   // 文章标题
+	URL           string    `json:"url"`                     // 文章链接
+	Snippet       string    `json:"snippet"`                 // 内容摘要
+	PublishedDate time.Time `json:"published_date,omitzero"` // 发布时间（如果可提取）
+}
+
+// 结果集的数据来源类型
+const (
+	ProvenanceLive   = "live"   // 实时从网络获取
+	ProvenanceCached = "cached" // 来自本地缓存
+	ProvenanceDemo   = "demo"   // 演示数据（模拟生成）
+)
+
+// 结果集的获取方式
+const (
+	ModeOfficial = "official" // 专注模式：官方信息源专用抓取器
+	ModeFeed     = "feed"     // 订阅源模式：读取 RSS/Atom 订阅源
+	ModeSearch   = "search"   // 普通模式：站内搜索
+)
+
+// Provenance 描述结果集的数据来源
+type Provenance struct {
+	Kind      string    `json:"kind"`       // 来源类型：live、cached 或 demo
+	FetchedAt time.Time `json:"fetched_at"` // 获取时间
+	Backend   string    `json:"backend"`    // 使用的搜索后端或抓取器类型
+	Synthetic bool      `json:"synthetic"`  // 是否为模拟生成的数据
+}
+
+// NewLiveProvenance 创建实时获取的数据来源
+func NewLiveProvenance(backend string) Provenance {
+	return Provenance{
+		Kind:      ProvenanceLive,
+		FetchedAt: time.Now(),
+		Backend:   backend,
+	}
+}
+
+// NewDemoProvenance 创建演示数据的数据来源
+func NewDemoProvenance() Provenance {
+	return Provenance{
+		Kind:      ProvenanceDemo,
+		FetchedAt: time.Now(),
+		Backend:   ProvenanceDemo,
+		Synthetic: true,
+	}
+}
+
+// IsLive 判断结果集是否为实时获取的真实数据
+func (p Provenance) IsLive() bool {
+	return p.Kind == ProvenanceLive && !p.Synthetic
+}
+
+// ResultSet 表示一个信息源的一次获取结果
+type ResultSet struct {
+	Source     string         `json:"source"`                // 信息源名称
+	SourceURL  string         `json:"source_url"`            // 信息源地址
+	Mode       string         `json:"mode"`                  // 获取方式：official、feed 或 search
+	Provenance Provenance     `json:"provenance"`            // 数据来源
+	Results    []SearchResult `json:"results"`               // 结果列表
+	NewOnly    bool           `json:"new_only,omitempty"`    // 是否只保留此前未见过的结果
+	KnownCount int            `json:"known_count,omitempty"` // 因此前见过而被过滤的结果数
 }
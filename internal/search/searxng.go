@@ -0,0 +1,83 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SearXNGBackend SearXNG JSON API 搜索后端
+type SearXNGBackend struct {
+	baseURL string
+	client  *http.Client
+}
+
+// searxngResponse SearXNG JSON 响应结构
+type searxngResponse struct {
+	Results []struct {
+		URL           string `json:"url"`
+		Title         string `json:"title"`
+		Content       string `json:"content"`
+		PublishedDate string `json:"publishedDate"`
+	} `json:"results"`
+}
+
+// Name 返回后端名称
+func (b *SearXNGBackend) Name() string {
+	return BackendSearXNG
+}
+
+// SearchPageURL 返回浏览器中的查询地址
+func (b *SearXNGBackend) SearchPageURL(query string) string {
+	return fmt.Sprintf("%s/search?q=%s", b.baseURL, url.QueryEscape(query))
+}
+
+// Search 调用 SearXNG 实例的 JSON 接口执行查询
+func (b *SearXNGBackend) Search(ctx context.Context, query string) ([]SearchResult, error) {
+	searchURL := fmt.Sprintf("%s/search?q=%s&format=json", b.baseURL, url.QueryEscape(query))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	setBrowserHeaders(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("网络请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("SearXNG 实例拒绝了 JSON 请求（状态码 403），请确认实例已在 search.formats 中启用 json")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("搜索请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	var data searxngResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("JSON解析失败: %w", err)
+	}
+
+	results := []SearchResult{}
+	for _, item := range data.Results {
+		result := SearchResult{
+			Title:   strings.TrimSpace(item.Title),
+			URL:     strings.TrimSpace(item.URL),
+			Snippet: strings.TrimSpace(item.Content),
+		}
+		if t, ok := ParseDate(item.PublishedDate); ok {
+			result.PublishedDate = t
+		}
+		if result.Title != "" && result.URL != "" {
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic 原子地写入文件：先写入同目录下的临时文件并 fsync，再重命名覆盖目标文件，
+// 写入过程中崩溃不会留下半截文件
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	// 出错时清理临时文件
+	ok := false
+	defer func() {
+		if !ok {
+			tmp.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	ok = true
+
+	syncDir(dir)
+	return nil
+}
+
+// syncDir 将目录项的变更刷入磁盘，使重命名持久化；部分平台不支持对目录 fsync，忽略错误
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
+
+// backupFile 将文件当前内容保存为 .bak，文件不存在时跳过
+func backupFile(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := WriteFileAtomic(path+".bak", data, 0644); err != nil {
+		return fmt.Errorf("无法写入备份文件: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"news4coder/internal/subscription"
+	"os"
+	"path/filepath"
+)
+
+// FileStorage 基于 JSON 文件的订阅存储，写入采用临时文件加原子重命名，并以文件锁串行化修改
+type FileStorage struct {
+	configPath string
+}
+
+// NewFile 创建使用指定配置文件的存储实例
+func NewFile(configPath string) *FileStorage {
+	return &FileStorage{configPath: configPath}
+}
+
+// ensureConfigDir 确保配置目录存在
+func (s *FileStorage) ensureConfigDir() error {
+	dir := filepath.Dir(s.configPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("无法创建配置目录: %w", err)
+	}
+	return nil
+}
+
+// Load 从配置文件加载订阅列表，旧版本的配置文件在内存中升级到当前版本
+//
+// Load 只读取文件，不加锁也不写入任何文件（配置文件总是被原子替换，读取不会看到写了一半的内容），
+// 可以用于 list、fetch 和 Shell 补全等只读场景。升级后的配置在下次保存时才写回。
+// 需要修改配置时使用 Update。
+func (s *FileStorage) Load() (*subscription.Config, error) {
+	return s.load()
+}
+
+// Save 保存订阅列表到配置文件，写入期间持有文件锁
+func (s *FileStorage) Save(config *subscription.Config) error {
+	// 确保配置目录存在
+	if err := s.ensureConfigDir(); err != nil {
+		return err
+	}
+
+	lock, err := acquireLock(s.configPath)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	return s.save(config)
+}
+
+// Update 在文件锁保护下完成一次“加载-修改-保存”：fn 修改配置，
+// 返回错误时不保存，错误原样返回。多个进程并发修改时依次执行，不会互相覆盖。
+func (s *FileStorage) Update(fn func(config *subscription.Config) error) error {
+	// 确保配置目录存在
+	if err := s.ensureConfigDir(); err != nil {
+		return err
+	}
+
+	lock, err := acquireLock(s.configPath)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	config, err := s.load()
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+
+	if err := fn(config); err != nil {
+		return err
+	}
+
+	if err := s.save(config); err != nil {
+		return fmt.Errorf("保存配置失败: %w", err)
+	}
+	return nil
+}
+
+// load 读取并解析配置文件，需要时在内存中执行迁移
+func (s *FileStorage) load() (*subscription.Config, error) {
+	return ReadConfig(s.configPath)
+}
+
+// ReadConfig 只读地加载配置文件：不加锁、不创建目录，旧版本的配置只在内存中升级，不会写回。
+// 文件不存在时返回空配置。适用于 Shell 补全等不允许产生副作用的场景
+func ReadConfig(path string) (*subscription.Config, error) {
+	// 如果配置文件不存在，返回空配置
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return &subscription.Config{Version: SchemaVersion, Subscriptions: []subscription.Subscription{}}, nil
+	}
+
+	// 读取配置文件
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("无法读取配置文件: %w", err)
+	}
+
+	// 检查结构版本
+	version, err := configVersion(data)
+	if err != nil {
+		return nil, fmt.Errorf("配置文件格式错误: %w", err)
+	}
+	if version > SchemaVersion {
+		return nil, fmt.Errorf("配置文件版本为 %d，高于当前程序支持的版本 %d，请升级 news4coder 后再使用", version, SchemaVersion)
+	}
+
+	if version < SchemaVersion {
+		if data, err = migrate(data, version); err != nil {
+			return nil, fmt.Errorf("配置文件升级失败: %w", err)
+		}
+	}
+
+	// 解析JSON
+	var config subscription.Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("配置文件格式错误: %w", err)
+	}
+
+	return &config, nil
+}
+
+// save 序列化并原子写入配置文件（调用方需持有文件锁），写入前将上一版本保存为 .bak；
+// 覆盖旧版本的配置文件时，额外将原始文件保存为 .v<旧版本>.bak。
+// 磁盘上的配置文件版本高于当前程序支持的版本时拒绝写入，避免丢失新版本的数据
+func (s *FileStorage) save(config *subscription.Config) error {
+	previous, err := os.ReadFile(s.configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("无法读取配置文件: %w", err)
+	}
+	if err == nil {
+		version, err := configVersion(previous)
+		if err == nil && version > SchemaVersion {
+			return fmt.Errorf("配置文件版本为 %d，高于当前程序支持的版本 %d，请升级 news4coder 后再修改", version, SchemaVersion)
+		}
+		if err == nil && version < SchemaVersion {
+			// 首次写入新版本前保留升级前的原始文件，按版本命名，不会被后续保存覆盖
+			if err := WriteFileAtomic(fmt.Sprintf("%s.v%d.bak", s.configPath, version), previous, 0644); err != nil {
+				return fmt.Errorf("无法备份配置文件: %w", err)
+			}
+		}
+	}
+
+	config.Version = SchemaVersion
+
+	// 序列化为JSON
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("无法序列化配置: %w", err)
+	}
+
+	// 保留上一版本
+	if err := backupFile(s.configPath); err != nil {
+		return err
+	}
+
+	// 写入文件
+	if err := WriteFileAtomic(s.configPath, data, 0644); err != nil {
+		return fmt.Errorf("无法写入配置文件: %w", err)
+	}
+
+	return nil
+}
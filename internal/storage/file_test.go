@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"news4coder/internal/subscription"
+)
+
+const legacyConfig = `{
+  "subscriptions": [
+    {"name": "Go 博客", "url": "https://go.dev/blog"}
+  ]
+}`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "subscriptions.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadMigratesInMemory(t *testing.T) {
+	path := writeConfig(t, legacyConfig)
+
+	config, err := NewFile(path).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Subscriptions) != 1 || config.Subscriptions[0].Name != "Go 博客" {
+		t.Fatalf("unexpected subscriptions: %+v", config.Subscriptions)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != legacyConfig {
+		t.Errorf("Load modified the config file:\n%s", data)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("Load created extra files: %v", names)
+	}
+}
+
+func TestLoadMissingDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	config, err := NewFile(filepath.Join(dir, "subscriptions.json")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Subscriptions) != 0 {
+		t.Errorf("got %d subscriptions, want 0", len(config.Subscriptions))
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("Load created the config directory")
+	}
+}
+
+func TestUpdatePersistsMigration(t *testing.T) {
+	path := writeConfig(t, legacyConfig)
+	store := NewFile(path)
+
+	err := store.Update(func(config *subscription.Config) error {
+		config.Subscriptions = append(config.Subscriptions, subscription.Subscription{
+			Name: "Rust 博客",
+			URL:  "https://blog.rust-lang.org",
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := os.ReadFile(path + ".v1.bak")
+	if err != nil {
+		t.Fatalf("missing versioned backup: %v", err)
+	}
+	if string(backup) != legacyConfig {
+		t.Errorf("versioned backup differs from the original:\n%s", backup)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, err := configVersion(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != SchemaVersion {
+		t.Errorf("saved version = %d, want %d", version, SchemaVersion)
+	}
+
+	config, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Subscriptions) != 2 {
+		t.Errorf("got %d subscriptions, want 2", len(config.Subscriptions))
+	}
+}
+
+func TestSaveRefusesNewerVersion(t *testing.T) {
+	newer := `{"version": 99, "subscriptions": []}`
+	path := writeConfig(t, newer)
+	store := NewFile(path)
+
+	if _, err := store.Load(); err == nil {
+		t.Error("Load accepted a newer config version")
+	}
+
+	config := &subscription.Config{Subscriptions: []subscription.Subscription{}}
+	if err := store.Save(config); err == nil || !strings.Contains(err.Error(), "99") {
+		t.Errorf("Save error = %v, want refusal mentioning version 99", err)
+	}
+	err := store.Update(func(*subscription.Config) error { return nil })
+	if err == nil {
+		t.Error("Update overwrote a newer config version")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != newer {
+		t.Errorf("config file was overwritten:\n%s", data)
+	}
+}
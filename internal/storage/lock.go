@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+)
+
+// fileLock 基于锁文件的进程间咨询锁
+//
+// 数据文件会被原子重命名替换，因此锁加在单独的 .lock 文件上。
+type fileLock struct {
+	file *os.File
+}
+
+// acquireLock 获取 path 对应的排他锁，其他进程持有锁时阻塞等待
+func acquireLock(path string) (*fileLock, error) {
+	file, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("无法打开锁文件: %w", err)
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("无法锁定文件 %s: %w", path, err)
+	}
+	return &fileLock{file: file}, nil
+}
+
+// release 释放锁
+func (l *fileLock) release() {
+	unlockFile(l.file)
+	l.file.Close()
+}
+
+// WithLock 在 path 对应的排他锁（path.lock）保护下执行 fn，
+// 供历史记录等其他需要“加载-修改-保存”的数据文件使用；path 所在目录需已存在
+func WithLock(path string, fn func() error) error {
+	lock, err := acquireLock(path)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+	return fn()
+}
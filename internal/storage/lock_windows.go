@@ -0,0 +1,49 @@
+//go:build windows
+
+package storage
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32         = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = kernel32.NewProc("LockFileEx")
+	procUnlockFileEx = kernel32.NewProc("UnlockFileEx")
+)
+
+// lockfileExclusiveLock LockFileEx 的排他锁标志（LOCKFILE_EXCLUSIVE_LOCK）
+const lockfileExclusiveLock = 0x00000002
+
+// lockFile 对文件加排他锁（LockFileEx），阻塞直到获得锁
+func lockFile(f *os.File) error {
+	var overlapped syscall.Overlapped
+	r, _, err := procLockFileEx.Call(
+		f.Fd(),
+		uintptr(lockfileExclusiveLock),
+		0,
+		1, 0,
+		uintptr(unsafe.Pointer(&overlapped)),
+	)
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+// unlockFile 释放文件锁
+func unlockFile(f *os.File) error {
+	var overlapped syscall.Overlapped
+	r, _, err := procUnlockFileEx.Call(
+		f.Fd(),
+		0,
+		1, 0,
+		uintptr(unsafe.Pointer(&overlapped)),
+	)
+	if r == 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"news4coder/internal/subscription"
+	"sync"
+)
+
+// MemoryStorage 内存中的订阅存储，不落盘，适用于测试和嵌入程序
+type MemoryStorage struct {
+	mu     sync.Mutex
+	config *subscription.Config
+}
+
+// NewMemory 创建内存存储实例，config 为初始数据（可以为 nil）
+func NewMemory(config *subscription.Config) *MemoryStorage {
+	if config == nil {
+		config = &subscription.Config{Subscriptions: []subscription.Subscription{}}
+	}
+	return &MemoryStorage{config: cloneConfig(config)}
+}
+
+// Load 返回当前配置的副本
+func (s *MemoryStorage) Load() (*subscription.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return cloneConfig(s.config), nil
+}
+
+// Save 保存配置的副本
+func (s *MemoryStorage) Save(config *subscription.Config) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config = cloneConfig(config)
+	return nil
+}
+
+// Update 在互斥锁保护下修改配置，fn 返回错误时保持原数据不变
+func (s *MemoryStorage) Update(fn func(config *subscription.Config) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config := cloneConfig(s.config)
+	if err := fn(config); err != nil {
+		return err
+	}
+	s.config = cloneConfig(config)
+	return nil
+}
+
+// cloneConfig 深拷贝配置，避免调用方修改已保存的数据
+func cloneConfig(config *subscription.Config) *subscription.Config {
+	clone := &subscription.Config{
+		Version:       SchemaVersion,
+		Subscriptions: make([]subscription.Subscription, len(config.Subscriptions)),
+	}
+	for i, sub := range config.Subscriptions {
+		sub.Tags = append([]string(nil), sub.Tags...)
+		clone.Subscriptions[i] = sub
+	}
+	return clone
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"news4coder/internal/subscription"
+)
+
+func TestMemoryLoadReturnsCopy(t *testing.T) {
+	initial := &subscription.Config{Subscriptions: []subscription.Subscription{
+		{Name: "Go 博客", URL: "https://go.dev/blog", Tags: []string{"go"}},
+	}}
+	store := NewMemory(initial)
+
+	// 修改传入的初始数据不影响存储
+	initial.Subscriptions[0].Name = "已修改"
+
+	config, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Subscriptions[0].Tags[0] = "rust"
+	config.Subscriptions = append(config.Subscriptions, subscription.Subscription{Name: "新增"})
+
+	again, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Subscriptions) != 1 {
+		t.Fatalf("got %d subscriptions, want 1", len(again.Subscriptions))
+	}
+	sub := again.Subscriptions[0]
+	if sub.Name != "Go 博客" || sub.Tags[0] != "go" {
+		t.Errorf("stored data changed through a loaded copy: %+v", sub)
+	}
+	if again.Version != SchemaVersion {
+		t.Errorf("Version = %d, want %d", again.Version, SchemaVersion)
+	}
+}
+
+func TestMemoryNilConfig(t *testing.T) {
+	config, err := NewMemory(nil).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Subscriptions == nil || len(config.Subscriptions) != 0 {
+		t.Errorf("Subscriptions = %#v, want empty slice", config.Subscriptions)
+	}
+}
+
+func TestMemoryUpdate(t *testing.T) {
+	store := NewMemory(&subscription.Config{Subscriptions: []subscription.Subscription{
+		{Name: "Go 博客", URL: "https://go.dev/blog", Tags: []string{"go"}},
+	}})
+
+	errFailed := errors.New("失败")
+	err := store.Update(func(config *subscription.Config) error {
+		config.Subscriptions[0].Name = "半途修改"
+		config.Subscriptions[0].Tags[0] = "rust"
+		config.Subscriptions = append(config.Subscriptions, subscription.Subscription{Name: "新增"})
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("Update error = %v, want %v", err, errFailed)
+	}
+
+	config, _ := store.Load()
+	if len(config.Subscriptions) != 1 || config.Subscriptions[0].Name != "Go 博客" || config.Subscriptions[0].Tags[0] != "go" {
+		t.Errorf("failed Update changed data: %+v", config.Subscriptions)
+	}
+
+	var kept *subscription.Config
+	err = store.Update(func(config *subscription.Config) error {
+		config.Subscriptions = append(config.Subscriptions, subscription.Subscription{Name: "新增"})
+		kept = config
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Update 返回后继续修改 fn 拿到的配置不影响存储
+	kept.Subscriptions[1].Name = "之后修改"
+
+	config, _ = store.Load()
+	if len(config.Subscriptions) != 2 || config.Subscriptions[1].Name != "新增" {
+		t.Errorf("after Update: %+v", config.Subscriptions)
+	}
+}
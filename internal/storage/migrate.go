@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion 当前配置文件结构版本，修改持久化结构时递增并在 migrations 中追加迁移步骤
+const SchemaVersion = 2
+
+// legacyVersion 没有 version 字段的配置文件视为版本 1
+const legacyVersion = 1
+
+// migration 将配置从 from 版本升级到 from+1 版本
+//
+// 迁移基于原始 JSON 对象进行，不依赖当前的结构体定义，
+// 这样旧版本的字段即使已从结构体中删除或改名也能被正确转换。
+type migration struct {
+	from        int
+	description string
+	apply       func(doc map[string]any) error
+}
+
+// migrations 按版本顺序排列的迁移步骤
+var migrations = []migration{
+	{
+		from:        1,
+		description: "添加 version 字段",
+		apply: func(doc map[string]any) error {
+			if doc["subscriptions"] == nil {
+				doc["subscriptions"] = []any{}
+			}
+			return nil
+		},
+	},
+}
+
+// configVersion 读取配置文件的结构版本
+func configVersion(data []byte) (int, error) {
+	var header struct {
+		Version *int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return 0, err
+	}
+	if header.Version == nil {
+		return legacyVersion, nil
+	}
+	return *header.Version, nil
+}
+
+// migrate 将 version 版本的配置逐步升级到 SchemaVersion
+func migrate(data []byte, version int) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	for version < SchemaVersion {
+		step, ok := findMigration(version)
+		if !ok {
+			return nil, fmt.Errorf("缺少从版本 %d 升级的迁移步骤", version)
+		}
+		if err := step.apply(doc); err != nil {
+			return nil, fmt.Errorf("从版本 %d 升级失败（%s）: %w", version, step.description, err)
+		}
+		version++
+		doc["version"] = version
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// findMigration 查找从指定版本开始的迁移步骤
+func findMigration(from int) (migration, bool) {
+	for _, m := range migrations {
+		if m.from == from {
+			return m, true
+		}
+	}
+	return migration{}, false
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	configFile = "subscriptions.json"
+	// legacyDir 早期版本使用的目录（~/.news4coder），其中已有订阅配置时继续使用
+	legacyDir = ".news4coder"
+	// appDir XDG 目录下的应用子目录
+	appDir = "news4coder"
+	// EnvHome 指定配置与数据目录的环境变量
+	EnvHome = "NEWS4CODER_HOME"
+)
+
+// XDG 规范中 XDG_CONFIG_HOME、XDG_DATA_HOME 未设置时的默认目录（相对用户主目录）
+var (
+	xdgConfigDefault = ".config"
+	xdgDataDefault   = filepath.Join(".local", "share")
+)
+
+// configPathOverride 通过 --config 指定的配置文件路径
+var configPathOverride string
+
+// SetConfigPath 指定配置文件路径（对应全局 --config 参数），为空时恢复默认查找规则
+func SetConfigPath(path string) {
+	configPathOverride = path
+}
+
+// ConfigPath 返回订阅配置文件路径，优先级：
+//  1. --config 参数（SetConfigPath）
+//  2. $NEWS4CODER_HOME/subscriptions.json
+//  3. ~/.news4coder/subscriptions.json（旧版本的安装，该文件已存在时）
+//  4. $XDG_CONFIG_HOME/news4coder/subscriptions.json（XDG_CONFIG_HOME 未设置时为 ~/.config）
+func ConfigPath() (string, error) {
+	if configPathOverride != "" {
+		return filepath.Abs(configPathOverride)
+	}
+
+	dir, err := resolveDir("XDG_CONFIG_HOME", xdgConfigDefault)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configFile), nil
+}
+
+// ConfigDir 返回配置目录（存放 sources.json 等）：指定了 --config 时为该配置文件所在目录，
+// 否则与 ConfigPath 的默认查找规则相同
+func ConfigDir() (string, error) {
+	if configPathOverride != "" {
+		return filepath.Abs(filepath.Dir(configPathOverride))
+	}
+	return resolveDir("XDG_CONFIG_HOME", xdgConfigDefault)
+}
+
+// DataDir 返回数据目录（存放 history.json 等），优先级：
+//  1. --config 参数指定的配置文件所在目录
+//  2. $NEWS4CODER_HOME
+//  3. ~/.news4coder（旧版本的安装，其中已有 subscriptions.json 时）
+//  4. $XDG_DATA_HOME/news4coder（XDG_DATA_HOME 未设置时为 ~/.local/share）
+func DataDir() (string, error) {
+	if configPathOverride != "" {
+		return filepath.Abs(filepath.Dir(configPathOverride))
+	}
+	return resolveDir("XDG_DATA_HOME", xdgDataDefault)
+}
+
+// resolveDir 按 NEWS4CODER_HOME、旧版本的 ~/.news4coder、指定的 XDG 变量（未设置时为 xdgDefault）
+// 的顺序确定目录，xdgDefault 为相对用户主目录的路径
+func resolveDir(xdgEnv, xdgDefault string) (string, error) {
+	if home := os.Getenv(EnvHome); home != "" {
+		return filepath.Abs(home)
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("无法获取用户主目录: %w", err)
+	}
+
+	// 兼容已有安装：旧目录中已有订阅配置时不迁移位置。
+	// 只判断目录是否存在并不可靠，写入历史记录等操作也可能创建该目录
+	legacy := filepath.Join(homeDir, legacyDir)
+	if info, err := os.Stat(filepath.Join(legacy, configFile)); err == nil && !info.IsDir() {
+		return legacy, nil
+	}
+
+	// XDG 规范要求使用绝对路径，相对路径忽略
+	if xdg := os.Getenv(xdgEnv); filepath.IsAbs(xdg) {
+		return filepath.Join(xdg, appDir), nil
+	}
+
+	return filepath.Join(homeDir, xdgDefault, appDir), nil
+}
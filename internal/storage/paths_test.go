@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPathLookupOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string // 值中的 {home} 替换为临时主目录
+		legacy   string            // "file"：~/.news4coder/subscriptions.json 已存在；"dir"：只有空目录
+		override string
+		config   string // 期望的 ConfigPath
+		data     string // 期望的 DataDir
+	}{
+		{
+			name:   "xdg defaults",
+			config: "{home}/.config/news4coder/subscriptions.json",
+			data:   "{home}/.local/share/news4coder",
+		},
+		{
+			name:   "xdg variables",
+			env:    map[string]string{"XDG_CONFIG_HOME": "{home}/cfg", "XDG_DATA_HOME": "{home}/data"},
+			config: "{home}/cfg/news4coder/subscriptions.json",
+			data:   "{home}/data/news4coder",
+		},
+		{
+			// 只设置 XDG_CONFIG_HOME 时，数据目录使用 XDG 默认值而不是 ~/.news4coder
+			name:   "only config home set",
+			env:    map[string]string{"XDG_CONFIG_HOME": "{home}/cfg"},
+			config: "{home}/cfg/news4coder/subscriptions.json",
+			data:   "{home}/.local/share/news4coder",
+		},
+		{
+			name:   "relative xdg ignored",
+			env:    map[string]string{"XDG_CONFIG_HOME": "cfg", "XDG_DATA_HOME": "data"},
+			config: "{home}/.config/news4coder/subscriptions.json",
+			data:   "{home}/.local/share/news4coder",
+		},
+		{
+			name:   "legacy install",
+			env:    map[string]string{"XDG_CONFIG_HOME": "{home}/cfg", "XDG_DATA_HOME": "{home}/data"},
+			legacy: "file",
+			config: "{home}/.news4coder/subscriptions.json",
+			data:   "{home}/.news4coder",
+		},
+		{
+			// 只有目录（例如被历史记录创建）不算旧版本的安装
+			name:   "legacy directory without config",
+			env:    map[string]string{"XDG_CONFIG_HOME": "{home}/cfg"},
+			legacy: "dir",
+			config: "{home}/cfg/news4coder/subscriptions.json",
+			data:   "{home}/.local/share/news4coder",
+		},
+		{
+			name:   "news4coder home",
+			env:    map[string]string{"NEWS4CODER_HOME": "{home}/n4c", "XDG_CONFIG_HOME": "{home}/cfg"},
+			legacy: "file",
+			config: "{home}/n4c/subscriptions.json",
+			data:   "{home}/n4c",
+		},
+		{
+			name:     "config flag",
+			env:      map[string]string{"NEWS4CODER_HOME": "{home}/n4c"},
+			legacy:   "file",
+			override: "{home}/proj/subs.json",
+			config:   "{home}/proj/subs.json",
+			data:     "{home}/proj",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			expand := func(s string) string {
+				return filepath.FromSlash(strings.ReplaceAll(s, "{home}", filepath.ToSlash(home)))
+			}
+
+			t.Setenv("HOME", home)
+			t.Setenv("USERPROFILE", home)
+			for _, key := range []string{EnvHome, "XDG_CONFIG_HOME", "XDG_DATA_HOME"} {
+				t.Setenv(key, expand(tt.env[key]))
+			}
+
+			legacy := filepath.Join(home, legacyDir)
+			switch tt.legacy {
+			case "file":
+				if err := os.MkdirAll(legacy, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(legacy, configFile), []byte(`{"version":2,"subscriptions":[]}`), 0644); err != nil {
+					t.Fatal(err)
+				}
+			case "dir":
+				if err := os.MkdirAll(legacy, 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			SetConfigPath(expand(tt.override))
+			t.Cleanup(func() { SetConfigPath("") })
+
+			config, err := ConfigPath()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := expand(tt.config); config != want {
+				t.Errorf("ConfigPath() = %s, want %s", config, want)
+			}
+			configDir, err := ConfigDir()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if configDir != filepath.Dir(config) {
+				t.Errorf("ConfigDir() = %s, want %s", configDir, filepath.Dir(config))
+			}
+			data, err := DataDir()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := expand(tt.data); data != want {
+				t.Errorf("DataDir() = %s, want %s", data, want)
+			}
+		})
+	}
+}
@@ -1,87 +1,32 @@
 package storage
 
-import (
-	"encoding/json"
-	"fmt"
-	"news4coder/internal/subscription"
-	"os"
-	"path/filepath"
-)
-
-const (
-	configDir  = ".news4coder"
-	configFile = "subscriptions.json"
-)
-
-// Storage 提供订阅数据的持久化功能
-type Storage struct {
-	configPath string
+import "news4coder/internal/subscription"
+
+// Storage 订阅数据的持久化接口
+//
+// 命令只依赖该接口，默认实现为 JSON 文件（FileStorage），
+// 测试和嵌入程序可以使用内存实现（MemoryStorage）或自行实现。
+type Storage interface {
+	// Load 加载订阅配置，返回的配置可以自由修改，不会影响已保存的数据；
+	// Load 不应修改已保存的数据，只读命令和 Shell 补全依赖这一点
+	Load() (*subscription.Config, error)
+	// Save 保存订阅配置，覆盖已保存的数据
+	Save(config *subscription.Config) error
+	// Update 原子地完成一次“加载-修改-保存”：fn 返回错误时不保存，错误原样返回
+	Update(fn func(config *subscription.Config) error) error
 }
 
-// New 创建新的存储实例
-func New() (*Storage, error) {
-	homeDir, err := os.UserHomeDir()
+// New 创建默认的文件存储实例，配置文件位置见 ConfigPath
+func New() (Storage, error) {
+	configPath, err := ConfigPath()
 	if err != nil {
-		return nil, fmt.Errorf("无法获取用户主目录: %w", err)
-	}
-
-	configPath := filepath.Join(homeDir, configDir, configFile)
-	return &Storage{configPath: configPath}, nil
-}
-
-// ensureConfigDir 确保配置目录存在
-func (s *Storage) ensureConfigDir() error {
-	dir := filepath.Dir(s.configPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("无法创建配置目录: %w", err)
-	}
-	return nil
-}
-
-// Load 从配置文件加载订阅列表
-func (s *Storage) Load() (*subscription.Config, error) {
-	// 确保配置目录存在
-	if err := s.ensureConfigDir(); err != nil {
 		return nil, err
 	}
 
-	// 如果配置文件不存在，返回空配置
-	if _, err := os.Stat(s.configPath); os.IsNotExist(err) {
-		return &subscription.Config{Subscriptions: []subscription.Subscription{}}, nil
-	}
-
-	// 读取配置文件
-	data, err := os.ReadFile(s.configPath)
-	if err != nil {
-		return nil, fmt.Errorf("无法读取配置文件: %w", err)
-	}
-
-	// 解析JSON
-	var config subscription.Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("配置文件格式错误: %w", err)
-	}
-
-	return &config, nil
+	return NewFile(configPath), nil
 }
 
-// Save 保存订阅列表到配置文件
-func (s *Storage) Save(config *subscription.Config) error {
-	// 确保配置目录存在
-	if err := s.ensureConfigDir(); err != nil {
-		return err
-	}
-
-	// 序列化为JSON
-	data, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return fmt.Errorf("无法序列化配置: %w", err)
-	}
-
-	// 写入文件
-	if err := os.WriteFile(s.configPath, data, 0644); err != nil {
-		return fmt.Errorf("无法写入配置文件: %w", err)
-	}
-
-	return nil
-}
+var (
+	_ Storage = (*FileStorage)(nil)
+	_ Storage = (*MemoryStorage)(nil)
+)
@@ -7,9 +7,13 @@ import (
 	"time"
 )
 
+// BackendValidator 校验搜索后端名称，名称无效时返回错误
+type BackendValidator func(name string) error
+
 // Manager 提供订阅管理功能
 type Manager struct {
-	config *Config
+	config          *Config
+	validateBackend BackendValidator
 }
 
 // NewManager 创建订阅管理器
@@ -17,61 +21,184 @@ func NewManager(config *Config) *Manager {
 	return &Manager{config: config}
 }
 
+// SetBackendValidator 设置 Add、Update 时使用的搜索后端校验函数，未设置时不校验 backend 字段
+func (m *Manager) SetBackendValidator(fn BackendValidator) {
+	m.validateBackend = fn
+}
+
 // GetConfig 获取当前配置
 func (m *Manager) GetConfig() *Config {
 	return m.config
 }
 
-// Add 添加新订阅
-func (m *Manager) Add(name, alias, urlStr string) error {
+// Add 添加新订阅，返回实际保存的订阅（标签已规范化、已设置创建时间）
+func (m *Manager) Add(sub Subscription) (*Subscription, error) {
+	sub.Tags = normalizeTags(sub.Tags)
+	if err := m.ValidateNew(sub); err != nil {
+		return nil, err
+	}
+
+	// 添加新订阅
+	sub.CreatedAt = time.Now()
+	m.config.Subscriptions = append(m.config.Subscriptions, sub)
+	return &sub, nil
+}
+
+// ValidateNew 校验待添加的订阅（字段及名称、别名是否重复），不修改配置；
+// 可在执行耗时操作（如发现订阅源）之前提前检查，Add 使用相同的校验
+func (m *Manager) ValidateNew(sub Subscription) error {
+	if err := m.validate(sub); err != nil {
+		return err
+	}
+
+	// 检查名称或别名是否已存在
+	return m.checkUnique(sub, -1)
+}
+
+// Update 更新订阅（按名称或别名查找），校验规则与 Add 相同，保留原创建时间；
+// 返回实际保存的订阅
+func (m *Manager) Update(nameOrAlias string, sub Subscription) (*Subscription, error) {
+	index := m.indexOf(nameOrAlias)
+	if index < 0 {
+		return nil, fmt.Errorf("订阅不存在: %s", nameOrAlias)
+	}
+
+	sub.Tags = normalizeTags(sub.Tags)
+	if err := m.validate(sub); err != nil {
+		return nil, err
+	}
+
+	// 检查名称或别名是否与其他订阅冲突
+	if err := m.checkUnique(sub, index); err != nil {
+		return nil, err
+	}
+
+	sub.CreatedAt = m.config.Subscriptions[index].CreatedAt
+	m.config.Subscriptions[index] = sub
+	return &sub, nil
+}
+
+// checkUnique 检查名称和别名是否与其他订阅的名称或别名重复，skip 为跳过比较的订阅下标（-1 表示不跳过）
+//
+// 名称和别名共用同一个查找空间（见 Get），因此名称也不能与其他订阅的别名相同，反之亦然。
+func (m *Manager) checkUnique(sub Subscription, skip int) error {
+	for i, existing := range m.config.Subscriptions {
+		if i == skip {
+			continue
+		}
+		if existing.Name == sub.Name {
+			return fmt.Errorf("订阅名称已存在: %s", sub.Name)
+		}
+		if existing.Alias != "" && existing.Alias == sub.Name {
+			return fmt.Errorf("订阅名称 %s 已被订阅 %s 用作别名", sub.Name, existing.Name)
+		}
+		if sub.Alias == "" {
+			continue
+		}
+		if existing.Alias == sub.Alias {
+			return fmt.Errorf("别名已存在: %s", sub.Alias)
+		}
+		if existing.Name == sub.Alias {
+			return fmt.Errorf("别名 %s 与已有订阅的名称重复", sub.Alias)
+		}
+	}
+	return nil
+}
+
+// indexOf 按名称或别名查找订阅下标，不存在时返回 -1
+func (m *Manager) indexOf(nameOrAlias string) int {
+	for i, sub := range m.config.Subscriptions {
+		if sub.Name == nameOrAlias || sub.Alias == nameOrAlias {
+			return i
+		}
+	}
+	return -1
+}
+
+// validate 校验订阅字段，设置了 BackendValidator 时同时校验搜索后端
+func (m *Manager) validate(sub Subscription) error {
+	if err := validateFields(sub); err != nil {
+		return err
+	}
+	if sub.Backend != "" && m.validateBackend != nil {
+		return m.validateBackend(sub.Backend)
+	}
+	return nil
+}
+
+// validateFields 校验订阅的基本字段
+func validateFields(sub Subscription) error {
 	// 验证名称
-	if strings.TrimSpace(name) == "" {
+	if strings.TrimSpace(sub.Name) == "" {
 		return fmt.Errorf("订阅名称不能为空")
 	}
 
-	if len(name) > 50 {
+	if len(sub.Name) > 50 {
 		return fmt.Errorf("订阅名称长度不能超过50个字符")
 	}
 
 	// 验证别名（如果提供）
-	if alias != "" {
-		if strings.Contains(alias, " ") {
+	if sub.Alias != "" {
+		if strings.Contains(sub.Alias, " ") {
 			return fmt.Errorf("别名不能包含空格")
 		}
-		if len(alias) > 20 {
+		if len(sub.Alias) > 20 {
 			return fmt.Errorf("别名长度不能超过20个字符")
 		}
 	}
 
 	// 验证URL格式
-	parsedURL, err := url.Parse(urlStr)
-	if err != nil {
-		return fmt.Errorf("URL格式无效: %w", err)
+	if err := validateURL(sub.URL); err != nil {
+		return err
 	}
 
-	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
-		return fmt.Errorf("URL必须是HTTP或HTTPS协议")
+	// 验证订阅源地址（如果提供）
+	if sub.FeedURL != "" {
+		if err := validateURL(sub.FeedURL); err != nil {
+			return fmt.Errorf("订阅源地址无效: %w", err)
+		}
 	}
 
-	// 检查名称或别名是否已存在
-	for _, sub := range m.config.Subscriptions {
-		if sub.Name == name {
-			return fmt.Errorf("订阅名称已存在: %s", name)
+	// 验证标签
+	for _, tag := range sub.Tags {
+		if strings.ContainsAny(tag, " ,") {
+			return fmt.Errorf("标签不能包含空格或逗号: %s", tag)
 		}
-		if alias != "" && sub.Alias == alias {
-			return fmt.Errorf("别名已存在: %s", alias)
+		if len(tag) > 20 {
+			return fmt.Errorf("标签长度不能超过20个字符: %s", tag)
 		}
 	}
 
-	// 添加新订阅
-	newSub := Subscription{
-		Name:      name,
-		Alias:     alias,
-		URL:       urlStr,
-		CreatedAt: time.Now(),
+	return nil
+}
+
+// normalizeTags 去除标签首尾空白、空标签和重复标签（不区分大小写）
+func normalizeTags(tags []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// validateURL 校验 URL 为 HTTP/HTTPS 协议
+func validateURL(urlStr string) error {
+	parsedURL, err := url.Parse(urlStr)
+	if err != nil {
+		return fmt.Errorf("URL格式无效: %w", err)
+	}
+
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return fmt.Errorf("URL必须是HTTP或HTTPS协议")
 	}
 
-	m.config.Subscriptions = append(m.config.Subscriptions, newSub)
 	return nil
 }
 
@@ -102,6 +229,17 @@ func (m *Manager) List() []Subscription {
 	return m.config.Subscriptions
 }
 
+// ListByTag 获取带有指定标签的订阅
+func (m *Manager) ListByTag(tag string) []Subscription {
+	var subs []Subscription
+	for _, sub := range m.config.Subscriptions {
+		if sub.HasTag(tag) {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
 // Get 根据名称或别名获取订阅
 func (m *Manager) Get(nameOrAlias string) (*Subscription, error) {
 	for _, sub := range m.config.Subscriptions {
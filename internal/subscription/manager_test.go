@@ -0,0 +1,199 @@
+package subscription
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestManager 创建包含两个订阅的管理器：A（别名 go）和 B（无别名）
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	m := NewManager(&Config{})
+	for _, sub := range []Subscription{
+		{Name: "A", Alias: "go", URL: "https://go.dev/blog"},
+		{Name: "B", URL: "https://blog.rust-lang.org"},
+	} {
+		if _, err := m.Add(sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return m
+}
+
+func TestAddRejectsNameAliasCollisions(t *testing.T) {
+	tests := []struct {
+		name string
+		sub  Subscription
+		want string
+	}{
+		{"duplicate name", Subscription{Name: "A", URL: "https://example.com"}, "订阅名称已存在"},
+		{"duplicate alias", Subscription{Name: "C", Alias: "go", URL: "https://example.com"}, "别名已存在"},
+		{"name equals other alias", Subscription{Name: "go", URL: "https://example.com"}, "用作别名"},
+		{"alias equals other name", Subscription{Name: "C", Alias: "B", URL: "https://example.com"}, "与已有订阅的名称重复"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager(t)
+			_, err := m.Add(tt.sub)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Add(%+v) error = %v, want %q", tt.sub, err, tt.want)
+			}
+			if n := len(m.List()); n != 2 {
+				t.Errorf("got %d subscriptions after rejected Add, want 2", n)
+			}
+			// 已有订阅仍可按名称和别名找到
+			if sub, err := m.Get("go"); err != nil || sub.Name != "A" {
+				t.Errorf("Get(go) = %+v, %v", sub, err)
+			}
+		})
+	}
+}
+
+func TestUpdateKeepsCreatedAt(t *testing.T) {
+	m := newTestManager(t)
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	m.config.Subscriptions[0].CreatedAt = created
+
+	_, err := m.Update("go", Subscription{
+		Name:      "A2",
+		Alias:     "golang",
+		URL:       "https://go.dev/blog",
+		Tags:      []string{" go ", "Go", "blog"},
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := m.Get("golang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Name != "A2" || !sub.CreatedAt.Equal(created) {
+		t.Errorf("updated = %+v, want name A2 and CreatedAt %v", sub, created)
+	}
+	if strings.Join(sub.Tags, ",") != "go,blog" {
+		t.Errorf("tags = %q, want normalized", sub.Tags)
+	}
+	if _, err := m.Get("go"); err == nil {
+		t.Error("old alias still resolves")
+	}
+}
+
+func TestUpdateRejectsCollisions(t *testing.T) {
+	tests := []struct {
+		name string
+		sub  Subscription
+		want string
+	}{
+		{"rename to other name", Subscription{Name: "A", URL: "https://blog.rust-lang.org"}, "订阅名称已存在"},
+		{"rename to other alias", Subscription{Name: "go", URL: "https://blog.rust-lang.org"}, "用作别名"},
+		{"alias to other alias", Subscription{Name: "B", Alias: "go", URL: "https://blog.rust-lang.org"}, "别名已存在"},
+		{"alias to other name", Subscription{Name: "B", Alias: "A", URL: "https://blog.rust-lang.org"}, "与已有订阅的名称重复"},
+		{"invalid url", Subscription{Name: "B", URL: "ftp://example.com"}, "HTTP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager(t)
+			_, err := m.Update("B", tt.sub)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Update error = %v, want %q", err, tt.want)
+			}
+			if sub, err := m.Get("B"); err != nil || sub.URL != "https://blog.rust-lang.org" {
+				t.Errorf("B changed after rejected Update: %+v, %v", sub, err)
+			}
+		})
+	}
+}
+
+func TestUpdateOwnNameAndAlias(t *testing.T) {
+	m := newTestManager(t)
+
+	// 保持名称和别名不变、只修改其他字段
+	if _, err := m.Update("A", Subscription{Name: "A", Alias: "go", URL: "https://go.dev/doc", Category: "官方"}); err != nil {
+		t.Fatalf("update keeping own name: %v", err)
+	}
+	// 名称改为自己当前的别名
+	if _, err := m.Update("A", Subscription{Name: "go", Alias: "go", URL: "https://go.dev/doc"}); err != nil {
+		t.Fatalf("rename to own alias: %v", err)
+	}
+	sub, err := m.Get("go")
+	if err != nil || sub.URL != "https://go.dev/doc" {
+		t.Errorf("Get(go) = %+v, %v", sub, err)
+	}
+
+	if _, err := m.Update("missing", Subscription{Name: "missing", URL: "https://example.com"}); err == nil {
+		t.Error("Update of a missing subscription succeeded")
+	}
+}
+
+func TestAddReturnsStoredSubscription(t *testing.T) {
+	m := newTestManager(t)
+
+	added, err := m.Add(Subscription{Name: "C", Alias: "c", URL: "https://example.com", Tags: []string{"beta", " Beta ", ""}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.Name != "C" || strings.Join(added.Tags, ",") != "beta" || added.CreatedAt.IsZero() {
+		t.Errorf("added = %+v", added)
+	}
+
+	stored, err := m.Get("c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Name != added.Name || !stored.CreatedAt.Equal(added.CreatedAt) || strings.Join(stored.Tags, ",") != "beta" {
+		t.Errorf("stored = %+v, added = %+v", stored, added)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		in   []string
+		want []string
+	}{
+		{nil, nil},
+		{[]string{""}, nil},
+		{[]string{" go ", "rust"}, []string{"go", "rust"}},
+		{[]string{"Go", "go", "GO"}, []string{"Go"}},
+		{[]string{"  ", "web", " Web", "perf"}, []string{"web", "perf"}},
+	}
+	for _, tt := range tests {
+		got := normalizeTags(tt.in)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") || len(got) != len(tt.want) {
+			t.Errorf("normalizeTags(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestListByTag(t *testing.T) {
+	m := NewManager(&Config{})
+	for _, sub := range []Subscription{
+		{Name: "A", URL: "https://a.example.com", Tags: []string{"Backend", "go"}},
+		{Name: "B", URL: "https://b.example.com", Tags: []string{"frontend"}},
+		{Name: "C", URL: "https://c.example.com", Tags: []string{"backend"}},
+		{Name: "D", URL: "https://d.example.com"},
+	} {
+		if _, err := m.Add(sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string][]string{
+		"backend":  {"A", "C"},
+		"BACKEND":  {"A", "C"},
+		"frontend": {"B"},
+		"back":     nil,
+		"":         nil,
+	}
+	for tag, want := range tests {
+		var got []string
+		for _, sub := range m.ListByTag(tag) {
+			got = append(got, sub.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("ListByTag(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
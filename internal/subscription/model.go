@@ -1,16 +1,34 @@
 package subscription
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Subscription 表示一个订阅源
 type Subscription struct {
-	Name      string    `json:"name"`       // 订阅名称
-	Alias     string    `json:"alias"`      // 别名/代号（用于快捷访问）
-	URL       string    `json:"url"`        // 网站地址
-	CreatedAt time.Time `json:"created_at"` // 创建时间
+	Name      string    `json:"name"`               // 订阅名称
+	Alias     string    `json:"alias"`              // 别名/代号（用于快捷访问）
+	URL       string    `json:"url"`                // 网站地址
+	FeedURL   string    `json:"feed_url,omitempty"` // RSS/Atom 订阅源地址（为空时使用站内搜索）
+	Backend   string    `json:"backend,omitempty"`  // 站内搜索后端（为空时使用全局设置）
+	Category  string    `json:"category,omitempty"` // 分类（OPML 导入导出时对应文件夹）
+	Tags      []string  `json:"tags,omitempty"`     // 标签（可多个，用于按领域批量获取）
+	CreatedAt time.Time `json:"created_at"`         // 创建时间
+}
+
+// HasTag 判断订阅是否带有指定标签（不区分大小写）
+func (s Subscription) HasTag(tag string) bool {
+	for _, t := range s.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
 }
 
 // Config 表示订阅配置文件结构
 type Config struct {
+	Version       int            `json:"version"`       // 配置文件结构版本，由 storage 维护
 	Subscriptions []Subscription `json:"subscriptions"` // 订阅列表
 }
@@ -0,0 +1,25 @@
+package subscription
+
+import "testing"
+
+func TestHasTag(t *testing.T) {
+	sub := Subscription{Name: "Go 博客", Tags: []string{"Go", "backend"}}
+
+	tests := map[string]bool{
+		"Go":      true,
+		"go":      true,
+		"BACKEND": true,
+		"back":    false,
+		"rust":    false,
+		"":        false,
+	}
+	for tag, want := range tests {
+		if got := sub.HasTag(tag); got != want {
+			t.Errorf("HasTag(%q) = %v, want %v", tag, got, want)
+		}
+	}
+
+	if (Subscription{Name: "无标签"}).HasTag("go") {
+		t.Error("subscription without tags matched")
+	}
+}
@@ -0,0 +1,189 @@
+package subscription
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+// opmlDocument OPML 2.0 文档结构
+type opmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    opmlHead `xml:"head"`
+	Body    opmlBody `xml:"body"`
+}
+
+// opmlHead OPML 文档头
+type opmlHead struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+// opmlBody OPML 文档主体
+type opmlBody struct {
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// opmlOutline OPML 条目；含 xmlUrl 或 htmlUrl 的为订阅，其余作为分类文件夹
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string        `xml:"htmlUrl,attr,omitempty"`
+	Category string        `xml:"category,attr,omitempty"`
+	Alias    string        `xml:"alias,attr,omitempty"`
+	Backend  string        `xml:"backend,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// ParseOPML 解析 OPML 文档，将订阅条目转换为订阅；
+// category 属性中以 "/" 开头的第一项作为分类（没有时使用所在文件夹的名称），
+// 其余不带 "/" 的项作为标签
+func ParseOPML(r io.Reader) ([]Subscription, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+
+	var doc opmlDocument
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("OPML 解析失败: %w", err)
+	}
+
+	var subs []Subscription
+	collectOutlines(doc.Body.Outlines, "", &subs)
+	return subs, nil
+}
+
+// collectOutlines 递归收集订阅条目，folder 为上级文件夹名称
+func collectOutlines(outlines []opmlOutline, folder string, subs *[]Subscription) {
+	for _, o := range outlines {
+		name := strings.TrimSpace(o.Text)
+		if name == "" {
+			name = strings.TrimSpace(o.Title)
+		}
+
+		feedURL := strings.TrimSpace(o.XMLURL)
+		siteURL := strings.TrimSpace(o.HTMLURL)
+		if feedURL == "" && siteURL == "" {
+			// 文件夹
+			collectOutlines(o.Outlines, name, subs)
+			continue
+		}
+
+		if siteURL == "" {
+			siteURL = siteURLFromFeed(feedURL)
+		}
+
+		category, tags := parseOPMLCategories(o.Category)
+		if category == "" {
+			category = folder
+		}
+
+		*subs = append(*subs, Subscription{
+			Name:     name,
+			Alias:    strings.TrimSpace(o.Alias),
+			URL:      siteURL,
+			FeedURL:  feedURL,
+			Backend:  strings.TrimSpace(o.Backend),
+			Category: category,
+			Tags:     tags,
+		})
+		collectOutlines(o.Outlines, folder, subs)
+	}
+}
+
+// parseOPMLCategories 解析 OPML category 属性（逗号分隔），
+// 例如 "/Tech/Go,/News,frontend" 得到分类 "Tech/Go" 和标签 ["frontend"]
+func parseOPMLCategories(s string) (string, []string) {
+	var category string
+	var tags []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		switch {
+		case item == "":
+		case strings.HasPrefix(item, "/"):
+			if category == "" {
+				category = strings.Trim(item, "/")
+			}
+		default:
+			tags = append(tags, item)
+		}
+	}
+	return category, tags
+}
+
+// formatOPMLCategories 生成 OPML category 属性，与 parseOPMLCategories 对应
+func formatOPMLCategories(category string, tags []string) string {
+	var items []string
+	if category != "" {
+		items = append(items, "/"+category)
+	}
+	return strings.Join(append(items, tags...), ",")
+}
+
+// siteURLFromFeed 条目没有 htmlUrl 时，以订阅源所在站点的根地址作为网站地址
+func siteURLFromFeed(feedURL string) string {
+	u, err := url.Parse(feedURL)
+	if err != nil || u.Host == "" {
+		return feedURL
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}).String()
+}
+
+// WriteOPML 将订阅导出为 OPML 2.0 文档，按分类分组到文件夹中
+func WriteOPML(w io.Writer, subs []Subscription) error {
+	doc := opmlDocument{
+		Version: "2.0",
+		Head: opmlHead{
+			Title:       "News4Coder 订阅",
+			DateCreated: time.Now().Format(time.RFC1123Z),
+		},
+	}
+
+	folders := make(map[string]int)
+	for _, sub := range subs {
+		outline := opmlOutline{
+			Text:    sub.Name,
+			Title:   sub.Name,
+			XMLURL:  sub.FeedURL,
+			HTMLURL: sub.URL,
+			Alias:   sub.Alias,
+			Backend: sub.Backend,
+		}
+		outline.Category = formatOPMLCategories(sub.Category, sub.Tags)
+		if sub.FeedURL != "" {
+			outline.Type = "rss"
+		}
+
+		if sub.Category == "" {
+			doc.Body.Outlines = append(doc.Body.Outlines, outline)
+			continue
+		}
+
+		i, ok := folders[sub.Category]
+		if !ok {
+			i = len(doc.Body.Outlines)
+			folders[sub.Category] = i
+			doc.Body.Outlines = append(doc.Body.Outlines, opmlOutline{Text: sub.Category, Title: sub.Category})
+		}
+		doc.Body.Outlines[i].Outlines = append(doc.Body.Outlines[i].Outlines, outline)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("OPML 生成失败: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
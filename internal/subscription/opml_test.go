@@ -0,0 +1,100 @@
+package subscription
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOPMLRoundTrip(t *testing.T) {
+	subs := []Subscription{
+		{Name: "Go 博客", Alias: "goblog", URL: "https://go.dev/blog", FeedURL: "https://go.dev/blog/feed.atom", Category: "Tech/Go", Tags: []string{"go", "official"}},
+		{Name: "Hacker News", Alias: "hn", URL: "https://news.ycombinator.com", Backend: "bing", Tags: []string{"news"}},
+		{Name: "InfoQ中文站", URL: "https://www.infoq.cn", FeedURL: "https://www.infoq.cn/feed", Category: "Tech/Go"},
+		{Name: "A & B <特殊字符>", URL: "https://example.com/?a=1&b=2", Category: "杂项"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOPML(&buf, subs); err != nil {
+		t.Fatalf("WriteOPML: %v", err)
+	}
+
+	got, err := ParseOPML(&buf)
+	if err != nil {
+		t.Fatalf("ParseOPML: %v\n%s", err, buf.String())
+	}
+
+	// 同一分类的订阅放在该分类首次出现位置的文件夹中
+	want := []Subscription{subs[0], subs[2], subs[1], subs[3]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip mismatch\ngot:  %+v\nwant: %+v", got, want)
+	}
+
+	// 导入到管理器后与原订阅一致
+	manager := NewManager(&Config{})
+	for _, sub := range got {
+		if _, err := manager.Add(sub); err != nil {
+			t.Errorf("Add(%s): %v", sub.Name, err)
+		}
+	}
+	if len(manager.List()) != len(subs) {
+		t.Errorf("imported %d subscriptions, want %d", len(manager.List()), len(subs))
+	}
+}
+
+func TestParseOPML(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="1.0">
+  <head><title>Feeds</title></head>
+  <body>
+    <outline text="技术">
+      <outline text="后端">
+        <outline text="Go Blog" type="rss" xmlUrl="https://go.dev/blog/feed.atom" htmlUrl="https://go.dev/blog"/>
+      </outline>
+      <outline title="只有 title" xmlUrl="https://example.org/feed.xml" category="/Tech/Web,frontend,perf"/>
+    </outline>
+    <outline text="没有订阅源地址的网站" htmlUrl="https://site.example.com/"/>
+    <outline text="重复名称" xmlUrl="https://a.example.com/feed"/>
+    <outline text="重复名称" xmlUrl="https://b.example.com/feed"/>
+    <outline text="空文件夹"/>
+  </body>
+</opml>`
+
+	got, err := ParseOPML(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Subscription{
+		// 嵌套文件夹使用最内层文件夹名称作为分类
+		{Name: "Go Blog", URL: "https://go.dev/blog", FeedURL: "https://go.dev/blog/feed.atom", Category: "后端"},
+		// 缺少 htmlUrl 时使用订阅源站点根地址；category 属性优先于文件夹
+		{Name: "只有 title", URL: "https://example.org/", FeedURL: "https://example.org/feed.xml", Category: "Tech/Web", Tags: []string{"frontend", "perf"}},
+		// 缺少 xmlUrl 时作为站内搜索订阅
+		{Name: "没有订阅源地址的网站", URL: "https://site.example.com/"},
+		{Name: "重复名称", URL: "https://a.example.com/", FeedURL: "https://a.example.com/feed"},
+		{Name: "重复名称", URL: "https://b.example.com/", FeedURL: "https://b.example.com/feed"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got:  %+v\nwant: %+v", got, want)
+	}
+
+	// 重复名称的条目在导入时被拒绝
+	manager := NewManager(&Config{})
+	var errs int
+	for _, sub := range got {
+		if _, err := manager.Add(sub); err != nil {
+			errs++
+		}
+	}
+	if errs != 1 || len(manager.List()) != len(want)-1 {
+		t.Errorf("got %d errors and %d subscriptions, want 1 and %d", errs, len(manager.List()), len(want)-1)
+	}
+}
+
+func TestParseOPMLInvalid(t *testing.T) {
+	if _, err := ParseOPML(strings.NewReader("not xml")); err == nil {
+		t.Error("expected error")
+	}
+}